@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+)
+
+func configCmd(args []string) error {
+	if len(args) == 0 || args[0] != "validate" {
+		return fmt.Errorf("usage: config validate")
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("config is invalid:\n%w", err)
+	}
+	fmt.Println("config is valid")
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository/connection"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository/migrations"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/service"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+)
+
+// env holds the dependencies shared by the admin commands.
+// ctx is cancelled on SIGINT or SIGTERM, aborting running queries.
+type env struct {
+	ctx    context.Context
+	stop   context.CancelFunc
+	cfg    *config.Configs
+	logger *zap.SugaredLogger
+	db     *pgxpool.Pool
+}
+
+func newEnv() (*env, error) {
+	logger, err := zap.NewDevelopment(zap.AddStacktrace(zap.PanicLevel))
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.New()
+	if err != nil {
+		return nil, fmt.Errorf("error while defining config: %w", err)
+	}
+	db, err := connection.NewPostgresDB(cfg.DB)
+	if err != nil {
+		return nil, fmt.Errorf("error while connecting to database: %w", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	return &env{ctx: ctx, stop: stop, cfg: cfg, logger: logger.Sugar(), db: db}, nil
+}
+
+func (e *env) close() {
+	e.stop()
+	e.db.Close()
+	e.logger.Sync()
+}
+
+func (e *env) services() *service.Service {
+	repos := repository.New(e.db, e.cfg, e.logger)
+	return service.New(repos, e.logger, e.cfg)
+}
+
+func migrateCmd(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down|status")
+	}
+
+	fs := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	steps := fs.Int("steps", 1, "number of migrations to revert (down only)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	e, err := newEnv()
+	if err != nil {
+		return err
+	}
+	defer e.close()
+
+	migrator, err := migrations.New(e.db, e.logger)
+	if err != nil {
+		return err
+	}
+
+	ctx := e.ctx
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		if *steps < 1 {
+			return fmt.Errorf("steps must be positive")
+		}
+		return migrator.Down(ctx, *steps)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+}
+
+func seedCmd(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	e, err := newEnv()
+	if err != nil {
+		return err
+	}
+	defer e.close()
+
+	migrator, err := migrations.New(e.db, e.logger)
+	if err != nil {
+		return err
+	}
+	return migrator.Seed(e.ctx)
+}
@@ -1,9 +1,57 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/app"
 )
 
+const usage = `usage: prog <command> [arguments]
+
+commands:
+  serve                          start the HTTP server (default)
+  migrate up|down|status         manage schema migrations
+  seed                           load the mock data set
+  positions export|import        move positions between environments as JSON lines
+  skills merge                   fold one skill into another
+  config validate                check config/config.yaml
+`
+
 func main() {
-	app.Run()
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return app.Run()
+	}
+
+	switch args[0] {
+	case "serve":
+		return app.Run()
+	case "migrate":
+		return migrateCmd(args[1:])
+	case "seed":
+		return seedCmd(args[1:])
+	case "positions":
+		return positionsCmd(args[1:])
+	case "skills":
+		return skillsCmd(args[1:])
+	case "config":
+		return configCmd(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
 }
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/service"
+)
+
+const exportPageSize = 100
+
+func positionsCmd(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: positions export|import")
+	}
+	switch args[0] {
+	case "export":
+		return exportPositions(args[1:])
+	case "import":
+		return importPositions(args[1:])
+	default:
+		return fmt.Errorf("unknown positions command %q", args[0])
+	}
+}
+
+func exportPositions(args []string) error {
+	fs := flag.NewFlagSet("positions export", flag.ContinueOnError)
+	output := fs.String("o", "", "output file (default stdout)")
+	company := fs.String("company", "", "export only positions of this company public id")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	e, err := newEnv()
+	if err != nil {
+		return err
+	}
+	defer e.close()
+	services := e.services()
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+	enc := json.NewEncoder(w)
+
+	exported := 0
+	// walk the listing by cursor so positions created meanwhile don't shift pages
+	page := &models.Pagination{PageNum: 1, PageSize: exportPageSize}
+	for {
+		var positions []models.Position
+		var info *models.PageInfo
+		if *company != "" {
+			positions, info, err = services.GetPositionsByCompany(e.ctx, *company, page, &models.PositionFilter{})
+		} else {
+			positions, info, err = services.GetAllPositions(e.ctx, &models.PositionFilter{}, page)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, p := range positions {
+			line, err := exportPosition(e.ctx, services, *p.PublicID)
+			if err != nil {
+				return err
+			}
+			if err := enc.Encode(line); err != nil {
+				return err
+			}
+			exported++
+		}
+		if info.NextCursor == "" {
+			break
+		}
+		page.Cursor = info.NextCursor
+	}
+
+	e.logger.Infof("exported %d positions", exported)
+	return nil
+}
+
+func exportPosition(ctx context.Context, services *service.Service, publicID string) (*models.PositionWithQuestions, error) {
+	position, err := services.GetPosition(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := services.GetPositionQuestions(ctx, &models.Viewer{View: models.ViewRecruiter}, publicID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PositionWithQuestions{Position: *position, Questions: questions}, nil
+}
+
+func importPositions(args []string) error {
+	fs := flag.NewFlagSet("positions import", flag.ContinueOnError)
+	input := fs.String("i", "", "input file (default stdin)")
+	recruiter := fs.String("recruiter", "", "assign every imported position to this recruiter public id")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	e, err := newEnv()
+	if err != nil {
+		return err
+	}
+	defer e.close()
+	services := e.services()
+
+	var in io.Reader = os.Stdin
+	if *input != "" {
+		f, err := os.Open(*input)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	// the whole file is read and checked first, then written in one transaction
+	dec := json.NewDecoder(in)
+	var positions []*models.PositionWithQuestions
+	for {
+		line := &models.PositionWithQuestions{}
+		err := dec.Decode(line)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("position %d: %w", len(positions)+1, err)
+		}
+
+		line.PublicID = nil
+		line.Company = nil
+		if *recruiter != "" {
+			line.RecruiterPublicID = recruiter
+		}
+		if line.RecruiterPublicID == nil {
+			return fmt.Errorf("position %d: recruiter_public_id is missing, use -recruiter", len(positions)+1)
+		}
+		if line.Status == nil {
+			status := models.PositionStatusDraft
+			line.Status = &status
+		}
+		for _, q := range line.Questions {
+			q.PublicID = ""
+		}
+		positions = append(positions, line)
+	}
+
+	created, err := services.ImportPositions(e.ctx, positions)
+	if err != nil {
+		return err
+	}
+	for _, publicID := range created {
+		fmt.Println(publicID)
+	}
+
+	e.logger.Infof("imported %d positions", len(created))
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+func skillsCmd(args []string) error {
+	if len(args) == 0 || args[0] != "merge" {
+		return fmt.Errorf("usage: skills merge -from <name> -into <name>")
+	}
+
+	fs := flag.NewFlagSet("skills merge", flag.ContinueOnError)
+	from := fs.String("from", "", "skill to remove")
+	into := fs.String("into", "", "skill that takes over positions and candidates")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	e, err := newEnv()
+	if err != nil {
+		return err
+	}
+	defer e.close()
+
+	if err := e.services().MergeSkills(e.ctx, *from, *into); err != nil {
+		return err
+	}
+	e.logger.Infof("merged skill %q into %q", *from, *into)
+	return nil
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/creasty/defaults"
@@ -8,14 +10,19 @@ import (
 )
 
 type Configs struct {
-	App   *AppConfig `json:"app" mapstructure:"app"`
-	DB    *DBConf    `json:"db" mapstructure:"db"`
-	Token *Token     `json:"token" mapstructure:"token"`
+	App        *AppConfig      `json:"app" mapstructure:"app" default:"{}"`
+	DB         *DBConf         `json:"db" mapstructure:"db" default:"{}"`
+	Token      *Token          `json:"token" mapstructure:"token"`
+	Interview  *InterviewConf  `json:"interview" mapstructure:"interview" default:"{}"`
+	Evaluation *EvaluationConf `json:"evaluation" mapstructure:"evaluation" default:"{}"`
 }
 
 type AppConfig struct {
 	TimeOut time.Duration `json:"timeout" mapstructure:"timeout"`
 	Port    int           `json:"port" mapstructure:"port"`
+	// Deadlines for handling a single read (GET) or write request, database calls included.
+	ReadTimeout  time.Duration `json:"read_timeout" mapstructure:"read_timeout" default:"10s"`
+	WriteTimeout time.Duration `json:"write_timeout" mapstructure:"write_timeout" default:"30s"`
 }
 
 type DBConf struct {
@@ -26,6 +33,28 @@ type DBConf struct {
 	DBName   string        `json:"dbname" mapstructure:"db_name"`
 	SSLMode  string        `json:"sslmode" mapstructure:"ssl_mode"`
 	TimeOut  time.Duration `json:"timeout" mapstructure:"timeout"`
+	Migrate  bool          `json:"migrate" mapstructure:"migrate" default:"true"`
+	Seed     bool          `json:"seed" mapstructure:"seed"`
+}
+
+type InterviewConf struct {
+	// TTL is how long a scheduled interview may wait to be started.
+	TTL time.Duration `json:"ttl" mapstructure:"ttl" default:"72h"`
+	// Duration is how long a started interview may take before it expires.
+	Duration      time.Duration `json:"duration" mapstructure:"duration" default:"2h"`
+	SweepInterval time.Duration `json:"sweep_interval" mapstructure:"sweep_interval" default:"1m"`
+	// MaxAttempts is the interview limit per candidate for positions without their own; 0 is unlimited.
+	MaxAttempts int `json:"max_attempts" mapstructure:"max_attempts" default:"3"`
+}
+
+type EvaluationConf struct {
+	// APIKey authenticates the evaluation pipeline posting interview results.
+	// Results are rejected while it is empty.
+	APIKey           string `json:"api_key" mapstructure:"api_key"`
+	MaxQuestionScore int    `json:"max_question_score" mapstructure:"max_question_score" default:"10"`
+	// PassThreshold is the normalized score, out of 100, an interview needs to
+	// pass at positions without their own threshold.
+	PassThreshold float64 `json:"pass_threshold" mapstructure:"pass_threshold" default:"60"`
 }
 
 type Token struct {
@@ -51,3 +80,58 @@ func New() (*Configs, error) {
 
 	return cfg, nil
 }
+
+// Validate reports every missing or out of range setting at once.
+func (c *Configs) Validate() error {
+	var errs []error
+	if c.App == nil {
+		errs = append(errs, errors.New("app: section is missing"))
+	} else {
+		if c.App.Port < 1 || c.App.Port > 65535 {
+			errs = append(errs, fmt.Errorf("app.port: %d is not a valid port", c.App.Port))
+		}
+		if c.App.TimeOut <= 0 {
+			errs = append(errs, errors.New("app.timeout: must be positive"))
+		}
+		if c.App.ReadTimeout <= 0 || c.App.WriteTimeout <= 0 {
+			errs = append(errs, errors.New("app.read_timeout, app.write_timeout: must be positive"))
+		}
+	}
+
+	if c.DB == nil {
+		errs = append(errs, errors.New("db: section is missing"))
+	} else {
+		if c.DB.Host == "" {
+			errs = append(errs, errors.New("db.host: must be set"))
+		}
+		if c.DB.Port < 1 || c.DB.Port > 65535 {
+			errs = append(errs, fmt.Errorf("db.port: %d is not a valid port", c.DB.Port))
+		}
+		if c.DB.DBName == "" {
+			errs = append(errs, errors.New("db.db_name: must be set"))
+		}
+		if c.DB.TimeOut <= 0 {
+			errs = append(errs, errors.New("db.timeout: must be positive"))
+		}
+	}
+
+	if c.Interview.TTL <= 0 || c.Interview.Duration <= 0 || c.Interview.SweepInterval <= 0 {
+		errs = append(errs, errors.New("interview.ttl, interview.duration, interview.sweep_interval: must be positive"))
+	}
+	if c.Interview.MaxAttempts < 0 {
+		errs = append(errs, errors.New("interview.max_attempts: must not be negative"))
+	}
+
+	if c.Evaluation.MaxQuestionScore <= 0 {
+		errs = append(errs, errors.New("evaluation.max_question_score: must be positive"))
+	}
+	if c.Evaluation.PassThreshold < 0 || c.Evaluation.PassThreshold > 100 {
+		errs = append(errs, errors.New("evaluation.pass_threshold: must be between 0 and 100"))
+	}
+
+	if c.Token == nil || c.Token.TokenSecret == "" {
+		errs = append(errs, errors.New("token.token_secret: must be set"))
+	}
+
+	return errors.Join(errs...)
+}
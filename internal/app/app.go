@@ -3,17 +3,21 @@ package app
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/Zhiyenbek/sp-positions-main-service/config"
 	handler "github.com/Zhiyenbek/sp-positions-main-service/internal/handler/http"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository/connection"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository/migrations"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/service"
+	"github.com/jackc/pgx/v4/pgxpool"
 	"go.uber.org/zap"
 )
 
@@ -34,10 +38,29 @@ func Run() error {
 		return err
 	}
 	defer db.Close()
+	if err := prepareDatabase(db, cfg, sugar); err != nil {
+		sugar.Errorf("error while preparing database: %v", err)
+		return err
+	}
 	repos := repository.New(db, cfg, sugar)
 	services := service.New(repos, sugar, cfg)
 	handlers := handler.New(services, sugar, cfg)
 
+	// interviews evaluated before scores were stored are left out of the
+	// rankings and statistics until they are scored
+	scored, err := services.ScoreInterviews(context.Background())
+	if err != nil {
+		sugar.Errorf("error while scoring interviews: %v", err)
+		return err
+	}
+	if scored > 0 {
+		sugar.Infof("scored %d interviews", scored)
+	}
+
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	defer stopSweep()
+	go sweepInterviews(sweepCtx, services, cfg.Interview.SweepInterval, sugar)
+
 	port, ok := os.LookupEnv("PORT")
 	if !ok {
 		log.Println("Couldn't get port. Using config port instead")
@@ -45,9 +68,14 @@ func Run() error {
 
 	}
 
+	// requests derive their context from base, so cancelling it aborts the
+	// queries of requests still running once shutdown gives up on them
+	base, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
 	srv := http.Server{
-		Addr:    ":" + port,
-		Handler: handlers.InitRoutes(),
+		Addr:        ":" + port,
+		Handler:     handlers.InitRoutes(),
+		BaseContext: func(net.Listener) context.Context { return base },
 	}
 	errChan := make(chan error, 1)
 	go func(errChan chan<- error) {
@@ -69,12 +97,59 @@ func Run() error {
 	}
 
 	log.Println("Shutting down server...")
+	stopSweep()
 
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.App.TimeOut)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		sugar.Errorf("WARN: Server forced to shutdown: %v", err)
+		cancelBase()
+	}
+	return nil
+
+}
+
+// prepareDatabase applies pending migrations and, when enabled, loads the mock data.
+func prepareDatabase(db *pgxpool.Pool, cfg *config.Configs, logger *zap.SugaredLogger) error {
+	if !cfg.DB.Migrate && !cfg.DB.Seed {
+		return nil
+	}
+	migrator, err := migrations.New(db, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if cfg.DB.Migrate {
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+	}
+	if cfg.DB.Seed {
+		if err := migrator.Seed(ctx); err != nil {
+			return err
+		}
 	}
 	return nil
+}
 
+// sweepInterviews expires abandoned interviews every interval until ctx is done.
+func sweepInterviews(ctx context.Context, services *service.Service, interval time.Duration, logger *zap.SugaredLogger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := services.ExpireInterviews(ctx)
+			if err != nil {
+				logger.Errorf("error while expiring interviews: %v", err)
+				continue
+			}
+			if expired > 0 {
+				logger.Infof("expired %d interviews", expired)
+			}
+		}
+	}
 }
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type GetBankQuestionsResult struct {
+	Questions []*models.BankQuestion `json:"questions"`
+	*models.PageInfo
+}
+
+type bankAttachmentsReq struct {
+	Questions []models.BankAttachment `json:"questions" binding:"required,min=1,dive"`
+}
+
+func (h *handler) CreateBankQuestion(c *gin.Context) {
+	companyPublicID := c.Param("company_public_id")
+
+	req := &models.BankQuestion{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when creating bank question: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+	req.CompanyPublicID = companyPublicID
+
+	res, err := h.service.QuestionBankService.CreateBankQuestion(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		case errors.Is(err, models.ErrCompanyDoesntExists):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrCompanyDoesntExists))
+		default:
+			h.log(c).Errorf("failed to create bank question of company %s: %v", companyPublicID, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, sendResponse(0, res, nil))
+}
+
+// GetBankQuestions lists the question bank of a company. It takes page_num,
+// page_size, search, type and tags (comma separated, all must match).
+func (h *handler) GetBankQuestions(c *gin.Context) {
+	companyPublicID := c.Param("company_public_id")
+	page := parsePagination(c)
+
+	filter := &models.BankFilter{
+		Search: strings.TrimSpace(c.Query("search")),
+		Type:   c.Query("type"),
+	}
+	if filter.Type != "" && filter.Type != models.QuestionTypeTech && filter.Type != models.QuestionTypeSoft {
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+	for _, value := range c.QueryArray("tags") {
+		filter.Tags = append(filter.Tags, strings.Split(value, ",")...)
+	}
+
+	res, count, err := h.service.QuestionBankService.GetBankQuestions(c.Request.Context(), companyPublicID, filter, page)
+	if err != nil {
+		h.log(c).Errorf("failed to get bank questions of company %s: %v", companyPublicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, GetBankQuestionsResult{
+		Questions: res,
+		PageInfo:  &models.PageInfo{Count: &count},
+	}, nil))
+}
+
+// UpdateBankQuestion changes the fields present in the body. Position questions
+// attached by reference are updated with it.
+func (h *handler) UpdateBankQuestion(c *gin.Context) {
+	publicID := c.Param("bank_question_public_id")
+
+	req := &models.BankQuestion{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when updating bank question: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+	req.PublicID = publicID
+
+	res, err := h.service.QuestionBankService.UpdateBankQuestion(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		case errors.Is(err, models.ErrBankQuestionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrBankQuestionNotFound))
+		default:
+			h.log(c).Errorf("failed to update bank question %s: %v", publicID, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+func (h *handler) DeleteBankQuestion(c *gin.Context) {
+	publicID := c.Param("bank_question_public_id")
+
+	if err := h.service.QuestionBankService.DeleteBankQuestion(c.Request.Context(), publicID); err != nil {
+		if errors.Is(err, models.ErrBankQuestionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrBankQuestionNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to delete bank question %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, nil, nil))
+}
+
+// AttachBankQuestions adds questions of the company's bank to a position, by
+// reference unless copy is set.
+func (h *handler) AttachBankQuestions(c *gin.Context) {
+	id := c.Param("position_public_id")
+
+	req := &bankAttachmentsReq{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when attaching bank questions: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+
+	res, err := h.service.QuestionBankService.AttachBankQuestions(c.Request.Context(), id, req.Questions)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		case errors.Is(err, models.ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+		case errors.Is(err, models.ErrBankQuestionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrBankQuestionNotFound))
+		default:
+			h.log(c).Errorf("failed to attach bank questions to position %s: %v", id, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, sendResponse(0, Questions{
+		PositionPublicID: id,
+		Questions:        res,
+	}, nil))
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+type GetCandidateInterviewsResult struct {
+	Interviews []*models.CandidateInterview `json:"interviews"`
+	*models.PageInfo
+}
+
+type GetCandidateApplicationsResult struct {
+	Applications []*models.Application `json:"applications"`
+	*models.PageInfo
+}
+
+// GetMyInterviews lists the interviews of the calling candidate, newest first.
+// It takes page_num and page_size.
+func (h *handler) GetMyInterviews(c *gin.Context) {
+	publicID := c.GetString("public_id")
+	page := parsePagination(c)
+
+	res, count, err := h.service.InterviewService.GetCandidateInterviews(c.Request.Context(), publicID, page)
+	if err != nil {
+		h.log(c).Errorf("failed to get interviews of candidate %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, GetCandidateInterviewsResult{
+		Interviews: res,
+		PageInfo:   &models.PageInfo{Count: &count},
+	}, nil))
+}
+
+// GetMyApplications lists the positions the calling candidate has interviewed
+// for, most recently active first. It takes page_num and page_size.
+func (h *handler) GetMyApplications(c *gin.Context) {
+	publicID := c.GetString("public_id")
+	page := parsePagination(c)
+
+	res, count, err := h.service.InterviewService.GetCandidateApplications(c.Request.Context(), publicID, page)
+	if err != nil {
+		h.log(c).Errorf("failed to get applications of candidate %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, GetCandidateApplicationsResult{
+		Applications: res,
+		PageInfo:     &models.PageInfo{Count: &count},
+	}, nil))
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetInterviewEmotions returns the emotion timeline of an interview.
+func (h *handler) GetInterviewEmotions(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	res, err := h.service.InterviewService.GetInterviewEmotions(c.Request.Context(), publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrInterviewNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to get emotions of interview %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+// GetPositionEmotions returns the emotion shares aggregated over the interviews of a position.
+func (h *handler) GetPositionEmotions(c *gin.Context) {
+	publicID := c.Param("position_public_id")
+
+	res, err := h.service.InterviewService.GetPositionEmotions(c.Request.Context(), publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to get emotions of position %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
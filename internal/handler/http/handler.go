@@ -29,21 +29,55 @@ func New(services *service.Service, logger *zap.SugaredLogger, cfg *config.Confi
 
 func (h *handler) InitRoutes() *gin.Engine {
 	router := gin.Default()
-	router.Use(cors.Default())
-	router.GET("/positions", h.GetPositions)
-	router.GET("/positions/:position_public_id/interviews", h.GetPositionInterviews)
-	router.GET("/position/:position_public_id", h.GetPosition)
-	router.POST("/position", middleware.VerifyToken(h.cfg.Token.TokenSecret, h.logger), h.CreatePosition)
-	router.POST("/position/:position_public_id/skills", h.AddSkillsToPosition)
-	router.DELETE("/position/:position_public_id/skills", h.DeleteSkillsFromPosition)
-	router.GET("/companies/:company_public_id/positions", h.GetPositionsByCompany)
-	router.GET("/recruiters/:recruiter_public_id/positions", h.GetPositionsByRecruiter)
-	router.POST("/position/:position_public_id/questions", h.AddQuestionsToPosition)
-	router.PUT("/question/:question_public_id", h.UpdateQuestion)
-	router.DELETE("/question/:question_public_id", h.DeleteQuestion)
-	router.GET("/position/:position_public_id/questions", h.GetQuestionsToPosition)
-	router.POST("/position/:position_public_id/interview", middleware.VerifyToken(h.cfg.Token.TokenSecret, h.logger), h.CreateInterview)
-	// router.PUT("/position", middleware.VerifyToken(h.cfg.Token.TokenSecret), h.UpdatePosition)
+	router.Use(cors.Default(), h.requestID())
+	read := deadline(h.cfg.App.ReadTimeout)
+	write := deadline(h.cfg.App.WriteTimeout)
+	auth := middleware.VerifyToken(h.cfg.Token.TokenSecret, h.logger)
+
+	router.GET("/positions", read, h.GetPositions)
+	router.GET("/positions/:position_public_id/interviews", read, optionalAuth(auth), h.GetPositionInterviews)
+	router.GET("/positions/:position_public_id/stats", read, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.GetPositionStats)
+	router.GET("/positions/:position_public_id/leaderboard", read, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.GetLeaderboard)
+	router.GET("/positions/:position_public_id/emotions", read, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.GetPositionEmotions)
+	router.GET("/position/:position_public_id", read, h.GetPosition)
+	router.POST("/position", write, auth, h.authorize(isRecruiter), h.CreatePosition)
+	router.PUT("/position/:position_public_id", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.UpdatePosition)
+	router.PATCH("/position/:position_public_id", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.UpdatePosition)
+	router.DELETE("/position/:position_public_id", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.DeletePosition)
+	router.POST("/position/:position_public_id/restore", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.RestorePosition)
+	router.POST("/position/:position_public_id/status", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.ChangePositionStatus)
+	router.POST("/position/:position_public_id/skills", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.AddSkillsToPosition)
+	router.DELETE("/position/:position_public_id/skills", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.DeleteSkillsFromPosition)
+	router.GET("/companies/:company_public_id/positions", read, h.GetPositionsByCompany)
+	router.GET("/recruiters/:recruiter_public_id/positions", read, h.GetPositionsByRecruiter)
+	router.POST("/position/:position_public_id/questions", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.AddQuestionsToPosition)
+	router.PUT("/question/:question_public_id", write, auth, h.authorize(isAdmin, h.isQuestionRecruiter), h.UpdateQuestion)
+	router.DELETE("/question/:question_public_id", write, auth, h.authorize(isAdmin, h.isQuestionRecruiter), h.DeleteQuestion)
+	router.GET("/position/:position_public_id/questions", read, optionalAuth(auth), h.GetQuestionsToPosition)
+	router.PUT("/position/:position_public_id/questions/order", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.ReorderQuestions)
+	router.GET("/position/:position_public_id/rubric", read, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.GetRubric)
+	router.PUT("/position/:position_public_id/rubric", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.UpdateRubric)
+	router.POST("/position/:position_public_id/questions/bank", write, auth, h.authorize(isAdmin, h.isCompanyRecruiter), h.AttachBankQuestions)
+	router.GET("/companies/:company_public_id/questions", read, auth, h.authorize(isAdmin, h.isCompanyMember), h.GetBankQuestions)
+	router.POST("/companies/:company_public_id/questions", write, auth, h.authorize(isAdmin, h.isCompanyMember), h.CreateBankQuestion)
+	router.PATCH("/question-bank/:bank_question_public_id", write, auth, h.authorize(isAdmin, h.isBankQuestionRecruiter), h.UpdateBankQuestion)
+	router.DELETE("/question-bank/:bank_question_public_id", write, auth, h.authorize(isAdmin, h.isBankQuestionRecruiter), h.DeleteBankQuestion)
+	router.POST("/position/:position_public_id/interview", write, auth, h.authorize(isCandidate), h.CreateInterview)
+	router.GET("/candidates/me/interviews", read, auth, h.authorize(isCandidate), h.GetMyInterviews)
+	router.GET("/candidates/me/applications", read, auth, h.authorize(isCandidate), h.GetMyApplications)
+	router.GET("/interview/:interview_public_id", read, auth, h.authorize(isAdmin, h.isInterviewCandidate, h.isInterviewRecruiter), h.GetInterview)
+	router.POST("/interview/:interview_public_id/start", write, auth, h.authorize(h.isInterviewCandidate), h.StartInterview)
+	router.POST("/interview/:interview_public_id/submit", write, auth, h.authorize(h.isInterviewCandidate), h.SubmitInterview)
+	router.POST("/interview/:interview_public_id/answers", write, auth, h.authorize(h.isInterviewCandidate), h.RegisterAnswer)
+	router.GET("/interview/:interview_public_id/answers", read, auth, h.authorize(isAdmin, h.isInterviewCandidate, h.isInterviewRecruiter), h.GetAnswers)
+	router.GET("/interview/:interview_public_id/emotions", read, auth, h.authorize(isAdmin, h.isInterviewRecruiter), h.GetInterviewEmotions)
+	router.POST("/interview/:interview_public_id/results", write, h.evaluationKey(), h.SubmitResults)
+	router.POST("/interview/:interview_public_id/cancel", write, auth, h.authorize(isAdmin, h.isInterviewCandidate), h.CancelInterview)
+	router.PUT("/interview/:interview_public_id/decision", write, auth, h.authorize(isAdmin, h.isInterviewRecruiter), h.Decide)
+	router.GET("/interview/:interview_public_id/decisions", read, auth, h.authorize(isAdmin, h.isInterviewRecruiter), h.GetDecisions)
+	router.POST("/interview/:interview_public_id/notes", write, auth, h.authorize(isAdmin, h.isInterviewRecruiter), h.AddNote)
+	router.GET("/interview/:interview_public_id/notes", read, auth, h.authorize(isAdmin, h.isInterviewRecruiter), h.GetNotes)
+	router.PUT("/interview/:interview_public_id/notes/:note_public_id", write, auth, h.authorize(isAdmin, h.isInterviewRecruiter), h.UpdateNote)
 	return router
 }
 
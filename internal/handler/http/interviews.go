@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type resultsResponse struct {
+	PublicID string `json:"public_id"`
+	Version  int    `json:"version"`
+}
+
+// GetInterview returns the interview with its position and the questions the
+// candidate has to answer.
+func (h *handler) GetInterview(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+	viewer, err := h.interviewViewer(c)
+	if err != nil {
+		h.log(c).Errorf("failed to resolve viewer of interview %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	res, err := h.service.InterviewService.GetInterview(c.Request.Context(), viewer, publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrInterviewNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to get interview %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+func (h *handler) StartInterview(c *gin.Context) {
+	h.changeInterviewStatus(c, h.service.InterviewService.StartInterview)
+}
+
+func (h *handler) SubmitInterview(c *gin.Context) {
+	h.changeInterviewStatus(c, h.service.InterviewService.SubmitInterview)
+}
+
+func (h *handler) CancelInterview(c *gin.Context) {
+	h.changeInterviewStatus(c, h.service.InterviewService.CancelInterview)
+}
+
+// changeInterviewStatus runs a lifecycle action on the interview in the path
+// and responds with the updated interview as the caller may see it.
+func (h *handler) changeInterviewStatus(c *gin.Context, action func(ctx context.Context, viewer *models.Viewer, publicID string) (*models.Interview, error)) {
+	publicID := c.Param("interview_public_id")
+	viewer, err := h.interviewViewer(c)
+	if err != nil {
+		h.log(c).Errorf("failed to resolve viewer of interview %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	res, err := action(c.Request.Context(), viewer, publicID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInterviewNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+		case errors.Is(err, models.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrInvalidTransition))
+		case errors.Is(err, models.ErrInterviewExpired):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrInterviewExpired))
+		default:
+			h.log(c).Errorf("failed to change interview %s status: %v", publicID, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+// SubmitResults stores the evaluation of a submitted interview. It is called by
+// the evaluation pipeline rather than by users.
+func (h *handler) SubmitResults(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	req := &models.Result{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when submitting results: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+
+	version, err := h.service.InterviewService.SubmitResults(c.Request.Context(), publicID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidResult):
+			c.JSON(http.StatusUnprocessableEntity, sendResponse(-1, nil, err))
+		case errors.Is(err, models.ErrInterviewNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+		case errors.Is(err, models.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrInvalidTransition))
+		default:
+			h.log(c).Errorf("failed to submit results of interview %s: %v", publicID, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, sendResponse(0, resultsResponse{
+		PublicID: publicID,
+		Version:  version,
+	}, nil))
+}
+
+// RegisterAnswer links an uploaded answer video, and optionally its transcript,
+// to a question of the candidate's running interview.
+func (h *handler) RegisterAnswer(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	req := &models.Answer{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when registering answer: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+	req.InterviewPublicID = publicID
+
+	res, err := h.service.InterviewService.RegisterAnswer(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInterviewNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+		case errors.Is(err, models.ErrQuestionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrQuestionNotFound))
+		case errors.Is(err, models.ErrInterviewNotStarted):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrInterviewNotStarted))
+		case errors.Is(err, models.ErrInterviewExpired):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrInterviewExpired))
+		case errors.Is(err, models.ErrRetakesExceeded):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrRetakesExceeded))
+		default:
+			h.log(c).Errorf("failed to register answer of interview %s: %v", publicID, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, sendResponse(0, res, nil))
+}
+
+func (h *handler) GetAnswers(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	res, err := h.service.InterviewService.GetAnswers(c.Request.Context(), publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrInterviewNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to get answers of interview %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+type GetLeaderboardResult struct {
+	Leaderboard []*models.LeaderboardEntry `json:"leaderboard"`
+	*models.PageInfo
+}
+
+// GetLeaderboard ranks the candidates of a position. It takes page_num and
+// page_size, min_score and status (interview statuses to rank, evaluated by default).
+func (h *handler) GetLeaderboard(c *gin.Context) {
+	publicID := c.Param("position_public_id")
+	page := parsePagination(c)
+
+	filter, err := parseLeaderboardFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+
+	res, count, err := h.service.InterviewService.GetLeaderboard(c.Request.Context(), publicID, filter, page)
+	if err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to get leaderboard of position %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, GetLeaderboardResult{
+		Leaderboard: res,
+		PageInfo:    &models.PageInfo{Count: &count},
+	}, nil))
+}
+
+func parseLeaderboardFilter(c *gin.Context) (*models.LeaderboardFilter, error) {
+	filter := &models.LeaderboardFilter{}
+
+	if value := c.Query("min_score"); value != "" {
+		minScore, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		filter.MinScore = &minScore
+	}
+
+	for _, value := range c.QueryArray("status") {
+		for _, name := range strings.Split(value, ",") {
+			status, err := models.ParseInterviewStatus(strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	return filter, nil
+}
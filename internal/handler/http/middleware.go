@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	roleCandidate = "candidate"
+	roleRecruiter = "recruiter"
+	roleAdmin     = "admin"
+)
+
+// policy decides whether the caller set by VerifyToken may proceed.
+// It returns models.ErrPermissionDenied to deny access.
+type policy func(c *gin.Context) error
+
+// authorize lets the request through when any of the policies allows it.
+// It must be chained after middleware.VerifyToken.
+func (h *handler) authorize(policies ...policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		denied := models.ErrPermissionDenied
+		for _, allow := range policies {
+			err := allow(c)
+			if err == nil {
+				c.Next()
+				return
+			}
+			if !errors.Is(err, models.ErrPermissionDenied) {
+				denied = err
+				break
+			}
+		}
+
+		switch {
+		case errors.Is(denied, models.ErrPermissionDenied):
+			c.AbortWithStatusJSON(http.StatusForbidden, sendResponse(-1, nil, models.ErrPermissionDenied))
+		case errors.Is(denied, models.ErrPositionNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+		case errors.Is(denied, models.ErrQuestionNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrQuestionNotFound))
+		case errors.Is(denied, models.ErrInterviewNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+		case errors.Is(denied, models.ErrBankQuestionNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrBankQuestionNotFound))
+		default:
+			h.log(c).Errorf("failed to authorize request: %v", denied)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+	}
+}
+
+func hasRole(role string) policy {
+	return func(c *gin.Context) error {
+		if c.GetString("role") != role {
+			return models.ErrPermissionDenied
+		}
+		return nil
+	}
+}
+
+var (
+	isAdmin     = hasRole(roleAdmin)
+	isCandidate = hasRole(roleCandidate)
+	isRecruiter = hasRole(roleRecruiter)
+)
+
+// optionalAuth verifies the access token only when the request carries one,
+// letting anonymous callers through without a role.
+func optionalAuth(verify gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, err := c.Cookie("access_token"); err != nil {
+			c.Next()
+			return
+		}
+		verify(c)
+	}
+}
+
+// viewer decides how the caller of a position route may see its interviews and questions:
+// admins and recruiters of the position's company get the recruiter view.
+func (h *handler) viewer(c *gin.Context) (*models.Viewer, error) {
+	viewer := &models.Viewer{View: models.ViewPublic, PublicID: c.GetString("public_id")}
+	switch c.GetString("role") {
+	case roleAdmin:
+		viewer.View = models.ViewRecruiter
+	case roleRecruiter:
+		err := h.isCompanyRecruiter(c)
+		if err == nil {
+			viewer.View = models.ViewRecruiter
+		} else if !errors.Is(err, models.ErrPermissionDenied) {
+			return nil, err
+		}
+	case roleCandidate:
+		viewer.View = models.ViewCandidate
+	}
+	return viewer, nil
+}
+
+// interviewViewer decides how the caller of an interview route may see it:
+// admins and recruiters of the interview get the recruiter view, its candidate
+// the candidate view.
+func (h *handler) interviewViewer(c *gin.Context) (*models.Viewer, error) {
+	viewer := &models.Viewer{View: models.ViewPublic, PublicID: c.GetString("public_id")}
+	var view string
+	var allowed policy
+	switch c.GetString("role") {
+	case roleAdmin:
+		viewer.View = models.ViewRecruiter
+		return viewer, nil
+	case roleRecruiter:
+		view, allowed = models.ViewRecruiter, h.isInterviewRecruiter
+	case roleCandidate:
+		view, allowed = models.ViewCandidate, h.isInterviewCandidate
+	default:
+		return viewer, nil
+	}
+	err := allowed(c)
+	if err == nil {
+		viewer.View = view
+	} else if !errors.Is(err, models.ErrPermissionDenied) {
+		return nil, err
+	}
+	return viewer, nil
+}
+
+func (h *handler) isCompanyRecruiter(c *gin.Context) error {
+	if err := isRecruiter(c); err != nil {
+		return err
+	}
+	return h.service.AuthorizationService.IsCompanyRecruiter(c.Request.Context(), c.GetString("public_id"), c.Param("position_public_id"))
+}
+
+func (h *handler) isQuestionRecruiter(c *gin.Context) error {
+	if err := isRecruiter(c); err != nil {
+		return err
+	}
+	return h.service.AuthorizationService.IsQuestionRecruiter(c.Request.Context(), c.GetString("public_id"), c.Param("question_public_id"))
+}
+
+func (h *handler) isCompanyMember(c *gin.Context) error {
+	if err := isRecruiter(c); err != nil {
+		return err
+	}
+	return h.service.AuthorizationService.IsCompanyMember(c.Request.Context(), c.GetString("public_id"), c.Param("company_public_id"))
+}
+
+func (h *handler) isBankQuestionRecruiter(c *gin.Context) error {
+	if err := isRecruiter(c); err != nil {
+		return err
+	}
+	return h.service.AuthorizationService.IsBankQuestionRecruiter(c.Request.Context(), c.GetString("public_id"), c.Param("bank_question_public_id"))
+}
+
+func (h *handler) isInterviewCandidate(c *gin.Context) error {
+	if err := isCandidate(c); err != nil {
+		return err
+	}
+	return h.service.AuthorizationService.IsInterviewCandidate(c.Request.Context(), c.GetString("public_id"), c.Param("interview_public_id"))
+}
+
+func (h *handler) isInterviewRecruiter(c *gin.Context) error {
+	if err := isRecruiter(c); err != nil {
+		return err
+	}
+	return h.service.AuthorizationService.IsInterviewRecruiter(c.Request.Context(), c.GetString("public_id"), c.Param("interview_public_id"))
+}
+
+// evaluationKey lets through only callers presenting the evaluation pipeline's
+// API key in the X-API-Key header.
+func (h *handler) evaluationKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := h.cfg.Evaluation.APIKey
+		key := c.GetHeader("X-API-Key")
+		if expected == "" || subtle.ConstantTimeCompare([]byte(key), []byte(expected)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, sendResponse(-1, nil, models.ErrPermissionDenied))
+			return
+		}
+		c.Next()
+	}
+}
+
+// requestID tags the request context with the X-Request-ID sent by the client,
+// or a generated one, and echoes it back in the response.
+func (h *handler) requestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if id == "" {
+			id = uuid.NewString()
+		}
+		c.Header(requestid.Header, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// deadline bounds the time the rest of the chain may spend on the request.
+// Queries still in flight when it passes are cancelled.
+func deadline(budget time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), budget)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func (h *handler) log(c *gin.Context) *zap.SugaredLogger {
+	return requestid.Logger(c.Request.Context(), h.logger)
+}
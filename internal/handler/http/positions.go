@@ -4,6 +4,8 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
 	"github.com/gin-gonic/gin"
@@ -13,33 +15,35 @@ import (
 
 type GetPositionsResult struct {
 	Positions []models.Position `json:"positions"`
-	Count     int               `json:"count"`
+	*models.PageInfo
 }
 type GetInteviewPosition struct {
-	Interviews []*models.Interview `json:"interviews"`
-	Count      int                 `json:"count"`
+	Interviews []models.InterviewView `json:"interviews"`
+	*models.PageInfo
 }
 type skillsReq struct {
 	Skills []string `json:"skills"`
 }
+type statusReq struct {
+	Status string `json:"status" binding:"required"`
+}
 
 func (h *handler) GetPositions(c *gin.Context) {
-	pageNum, err := strconv.Atoi(c.Query("page_num"))
-	if err != nil || pageNum < 1 {
-		pageNum = models.DefaultPageNum
-	}
-	pageSize, err := strconv.Atoi(c.Query("page_size"))
-	if err != nil || pageSize < 1 {
-		pageSize = models.DefaultPageSize
+	page := parsePagination(c)
+
+	filter, err := parsePositionFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
 	}
 
-	res, count, err := h.service.GetAllPositions(c.Query("search"), pageNum, pageSize)
+	res, info, err := h.service.GetAllPositions(c.Request.Context(), filter, page)
 	if err != nil {
 		var errMsg error
 		var code int
 		switch {
-		case errors.Is(err, models.ErrUsernameExists):
-			errMsg = models.ErrUsernameExists
+		case errors.Is(err, models.ErrInvalidCursor):
+			errMsg = models.ErrInvalidCursor
 			code = http.StatusBadRequest
 		default:
 			errMsg = models.ErrInternalServer
@@ -51,14 +55,138 @@ func (h *handler) GetPositions(c *gin.Context) {
 
 	c.JSON(http.StatusOK, sendResponse(0, GetPositionsResult{
 		Positions: res,
-		Count:     count,
+		PageInfo:  info,
 	}, nil))
 }
 
+var positionSorts = map[string]int{
+	"created_at":      models.SortByCreatedAt,
+	"name":            models.SortByName,
+	"interview_count": models.SortByInterviewCount,
+	"average_score":   models.SortByAverageScore,
+	"relevance":       models.SortByRelevance,
+}
+
+// parsePositionFilter reads the listing filters shared by every position listing:
+// search, skills, skills_match (any|all), company, recruiter, status,
+// created_from, created_to, sort and order (asc|desc).
+func parsePositionFilter(c *gin.Context) (*models.PositionFilter, error) {
+	filter := &models.PositionFilter{
+		Search:            c.Query("search"),
+		SkillsMatch:       models.DefaultTechnologyType,
+		CompanyPublicID:   c.Query("company"),
+		RecruiterPublicID: c.Query("recruiter"),
+		Sort:              models.DefaultSortType,
+	}
+
+	for _, skills := range c.QueryArray("skills") {
+		filter.Skills = append(filter.Skills, strings.Split(skills, ",")...)
+	}
+	switch c.Query("skills_match") {
+	case "", "any":
+	case "all":
+		filter.SkillsMatch = models.TechnologyMatchAll
+	default:
+		return nil, models.ErrInvalidInput
+	}
+
+	for _, id := range []string{filter.CompanyPublicID, filter.RecruiterPublicID} {
+		if id != "" {
+			if err := uuid.Validate(id); err != nil {
+				return nil, models.ErrInvalidInput
+			}
+		}
+	}
+
+	if name := c.Query("status"); name != "" {
+		status, err := models.ParsePositionStatus(name)
+		if err != nil {
+			return nil, err
+		}
+		filter.Status = &status
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseDate(c.Query("created_from"), false); err != nil {
+		return nil, err
+	}
+	if filter.CreatedTo, err = parseDate(c.Query("created_to"), true); err != nil {
+		return nil, err
+	}
+
+	sort := c.Query("sort")
+	switch {
+	case sort != "":
+		s, ok := positionSorts[sort]
+		if !ok {
+			return nil, models.ErrInvalidInput
+		}
+		filter.Sort = s
+	case strings.TrimSpace(filter.Search) != "":
+		filter.Sort = models.SortByRelevance
+	}
+
+	switch c.Query("order") {
+	case "":
+		filter.Ascending = filter.Sort == models.SortByName
+	case "asc":
+		filter.Ascending = true
+	case "desc":
+	default:
+		return nil, models.ErrInvalidInput
+	}
+
+	return filter, nil
+}
+
+// parseDate accepts either a RFC 3339 timestamp or a plain date. With endOfDay
+// a plain date covers the whole day, so it can be used as an inclusive upper bound.
+func parseDate(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, models.ErrInvalidInput
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
+
+// parsePagination reads page_num and page_size, or a cursor returned as
+// next_cursor/prev_cursor by a previous page. The total count is returned in
+// offset mode unless with_count=false, and in cursor mode only with with_count=true.
+func parsePagination(c *gin.Context) *models.Pagination {
+	page := &models.Pagination{
+		Cursor: c.Query("cursor"),
+	}
+	pageNum, err := strconv.Atoi(c.Query("page_num"))
+	if err != nil || pageNum < 1 {
+		pageNum = models.DefaultPageNum
+	}
+	pageSize, err := strconv.Atoi(c.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = models.DefaultPageSize
+	}
+	page.PageNum, page.PageSize = pageNum, pageSize
+
+	withCount, err := strconv.ParseBool(c.Query("with_count"))
+	if err != nil {
+		withCount = page.Cursor == ""
+	}
+	page.WithCount = withCount
+	return page
+}
+
 func (h *handler) GetPositionInterviews(c *gin.Context) {
 	publicID := c.Param("position_public_id")
 
-	err := h.service.PositionService.Exists(publicID)
+	err := h.service.PositionService.Exists(c.Request.Context(), publicID)
 	if err != nil {
 		if errors.Is(err, models.ErrPositionNotFound) {
 			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
@@ -67,29 +195,37 @@ func (h *handler) GetPositionInterviews(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
 	}
-	pageNum, err := strconv.Atoi(c.Query("page_num"))
-	if err != nil || pageNum < 1 {
-		pageNum = models.DefaultPageNum
+	viewer, err := h.viewer(c)
+	if err != nil {
+		h.log(c).Errorf("failed to resolve viewer of position %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
 	}
-	pageSize, err := strconv.Atoi(c.Query("page_size"))
-	if err != nil || pageSize < 1 {
-		pageSize = models.DefaultPageSize
+	page := parsePagination(c)
+	filter, err := parseInterviewFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
 	}
-	res, count, err := h.service.PositionService.GetPositionInterviews(publicID, pageNum, pageSize)
+	res, info, err := h.service.PositionService.GetPositionInterviews(c.Request.Context(), viewer, publicID, filter, page)
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidCursor))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
 	}
 	c.JSON(http.StatusOK, sendResponse(0, GetInteviewPosition{
 		Interviews: res,
-		Count:      count,
+		PageInfo:   info,
 	}, nil))
 }
 
 func (h *handler) GetPosition(c *gin.Context) {
 	publicID := c.Param("position_public_id")
 
-	err := h.service.PositionService.Exists(publicID)
+	err := h.service.PositionService.Exists(c.Request.Context(), publicID)
 	if err != nil {
 		if errors.Is(err, models.ErrPositionNotFound) {
 			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
@@ -99,8 +235,12 @@ func (h *handler) GetPosition(c *gin.Context) {
 		return
 	}
 
-	res, err := h.service.GetPosition(publicID)
+	res, err := h.service.GetPosition(c.Request.Context(), publicID)
 	if err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
 	}
@@ -112,15 +252,15 @@ func (h *handler) CreatePosition(c *gin.Context) {
 
 	req := &models.Position{}
 	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
-		h.logger.Errorf("failed to parse request body when creating position. %s\n", err.Error())
+		h.log(c).Errorf("failed to parse request body when creating position. %s\n", err.Error())
 		c.AbortWithStatusJSON(400, sendResponse(-1, nil, models.ErrInvalidInput))
 		return
 	}
 
 	req.RecruiterPublicID = &publicID
-	a := 0
-	req.Status = &a
-	res, err := h.service.PositionService.CreatePosition(req)
+	status := models.PositionStatusDraft
+	req.Status = &status
+	res, err := h.service.PositionService.CreatePosition(c.Request.Context(), req)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
@@ -128,25 +268,142 @@ func (h *handler) CreatePosition(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, sendResponse(0, res, nil))
 }
+
+func (h *handler) UpdatePosition(c *gin.Context) {
+	publicID := c.Param("position_public_id")
+	err := h.service.PositionService.Exists(c.Request.Context(), publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	req := &models.Position{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when updating position. %s\n", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+
+	// ownership and company are not editable through this endpoint
+	req.PublicID = &publicID
+	req.RecruiterPublicID = nil
+	req.Company = nil
+	res, err := h.service.PositionService.UpdatePosition(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+		case errors.Is(err, models.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrInvalidTransition))
+		default:
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+func (h *handler) ChangePositionStatus(c *gin.Context) {
+	publicID := c.Param("position_public_id")
+	err := h.service.PositionService.Exists(c.Request.Context(), publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	req := &statusReq{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when changing position status. %s\n", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+	status, err := models.ParsePositionStatus(req.Status)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+
+	res, err := h.service.PositionService.ChangePositionStatus(c.Request.Context(), publicID, status)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+		case errors.Is(err, models.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrInvalidTransition))
+		default:
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+func (h *handler) DeletePosition(c *gin.Context) {
+	publicID := c.Param("position_public_id")
+	hard := c.Query("hard") == "true"
+	if hard && c.GetString("role") != roleAdmin {
+		c.JSON(http.StatusForbidden, sendResponse(-1, nil, models.ErrPermissionDenied))
+		return
+	}
+
+	err := h.service.PositionService.DeletePosition(c.Request.Context(), publicID, hard)
+	if err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, nil, nil))
+}
+
+func (h *handler) RestorePosition(c *gin.Context) {
+	publicID := c.Param("position_public_id")
+
+	res, err := h.service.PositionService.RestorePosition(c.Request.Context(), publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
 func (h *handler) AddSkillsToPosition(c *gin.Context) {
 	req := &skillsReq{}
 	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
-		h.logger.Errorf("Failed to parse request body when adding skills to position: %s\n", err.Error())
+		h.log(c).Errorf("Failed to parse request body when adding skills to position: %s\n", err.Error())
 		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
 		return
 	}
 
 	publicID := c.Param("position_public_id") // Assuming the position public ID is in the URL path
-	if err := h.service.PositionService.Exists(publicID); err != nil {
-		if errors.Is(err, models.ErrPermissionDenied) {
-			c.JSON(http.StatusUnauthorized, sendResponse(-1, nil, models.ErrPermissionDenied))
+	if err := h.service.PositionService.Exists(c.Request.Context(), publicID); err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
 			return
 		}
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
 	}
 
-	err := h.service.PositionService.CreateSkillsForPosition(publicID, req.Skills)
+	err := h.service.PositionService.CreateSkillsForPosition(c.Request.Context(), publicID, req.Skills)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
@@ -158,22 +415,22 @@ func (h *handler) AddSkillsToPosition(c *gin.Context) {
 func (h *handler) DeleteSkillsFromPosition(c *gin.Context) {
 	req := &skillsReq{}
 	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
-		h.logger.Errorf("Failed to parse request body when deleting skills from position: %s\n", err.Error())
+		h.log(c).Errorf("Failed to parse request body when deleting skills from position: %s\n", err.Error())
 		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
 		return
 	}
 
 	publicID := c.Param("position_public_id") // Assuming the position public ID is in the URL path
-	if err := h.service.PositionService.Exists(publicID); err != nil {
-		if errors.Is(err, models.ErrPermissionDenied) {
-			c.JSON(http.StatusUnauthorized, sendResponse(-1, nil, models.ErrPermissionDenied))
+	if err := h.service.PositionService.Exists(c.Request.Context(), publicID); err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
 			return
 		}
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
 	}
 
-	err := h.service.DeleteSkillsFromPosition(publicID, req.Skills)
+	err := h.service.DeleteSkillsFromPosition(c.Request.Context(), publicID, req.Skills)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
@@ -183,23 +440,25 @@ func (h *handler) DeleteSkillsFromPosition(c *gin.Context) {
 }
 func (h *handler) GetPositionsByCompany(c *gin.Context) {
 	companyID := c.Param("company_public_id")
-	pageNum, err := strconv.Atoi(c.Query("page_num"))
-	if err != nil || pageNum < 1 {
-		pageNum = models.DefaultPageNum
-	}
-	pageSize, err := strconv.Atoi(c.Query("page_size"))
-	if err != nil || pageSize < 1 {
-		pageSize = models.DefaultPageSize
+	page := parsePagination(c)
+	filter, err := parsePositionFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
 	}
-	positions, count, err := h.service.PositionService.GetPositionsByCompany(companyID, pageNum, pageSize, c.Query("search"))
+	positions, info, err := h.service.PositionService.GetPositionsByCompany(c.Request.Context(), companyID, page, filter)
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidCursor))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
 	}
 
 	c.JSON(http.StatusOK, sendResponse(0, GetPositionsResult{
 		Positions: positions,
-		Count:     count,
+		PageInfo:  info,
 	}, nil))
 }
 
@@ -207,42 +466,49 @@ func (h *handler) GetPositionsByRecruiter(c *gin.Context) {
 	id := c.Param("recruiter_public_id")
 	if err := uuid.Validate(id); err != nil {
 		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
 	}
-	pageNum, err := strconv.Atoi(c.Query("page_num"))
-	if err != nil || pageNum < 1 {
-		pageNum = models.DefaultPageNum
-	}
-	pageSize, err := strconv.Atoi(c.Query("page_size"))
-	if err != nil || pageSize < 1 {
-		pageSize = models.DefaultPageSize
+	page := parsePagination(c)
+	filter, err := parsePositionFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
 	}
-	positions, count, err := h.service.PositionService.GetPositionsByRecruiter(id, pageNum, pageSize, c.Query("search"))
+	positions, info, err := h.service.PositionService.GetPositionsByRecruiter(c.Request.Context(), id, page, filter)
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidCursor))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
 	}
 
 	c.JSON(http.StatusOK, sendResponse(0, GetPositionsResult{
 		Positions: positions,
-		Count:     count,
+		PageInfo:  info,
 	}, nil))
 }
 
 type Questions struct {
 	PositionPublicID string             `json:"position_public_id"`
-	Questions        []*models.Question `json:"questions"`
+	Questions        []*models.Question `json:"questions" binding:"dive"`
+}
+
+type questionOrderReq struct {
+	QuestionPublicIDs []string `json:"question_public_ids" binding:"required,dive,uuid"`
 }
 
 func (h *handler) AddQuestionsToPosition(c *gin.Context) {
 	id := c.Param("position_public_id")
 	req := &Questions{}
 	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
-		h.logger.Errorf("Failed to parse request body when deleting skills from position: %s\n", err.Error())
+		h.log(c).Errorf("Failed to parse request body when deleting skills from position: %s\n", err.Error())
 		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
 		return
 	}
 
-	res, err := h.service.AddQuestionsToPosition(id, req.Questions)
+	res, err := h.service.AddQuestionsToPosition(c.Request.Context(), id, req.Questions)
 	if err != nil {
 		if errors.Is(err, models.ErrPositionNotFound) {
 			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
@@ -260,7 +526,7 @@ func (h *handler) AddQuestionsToPosition(c *gin.Context) {
 
 func (h *handler) GetQuestionsToPosition(c *gin.Context) {
 	id := c.Param("position_public_id")
-	err := h.service.PositionService.Exists(id)
+	err := h.service.PositionService.Exists(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, models.ErrPositionNotFound) {
 			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
@@ -269,8 +535,14 @@ func (h *handler) GetQuestionsToPosition(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
 		return
 	}
+	viewer, err := h.viewer(c)
+	if err != nil {
+		h.log(c).Errorf("failed to resolve viewer of position %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
 
-	res, err := h.service.GetPositionQuestions(id)
+	res, err := h.service.GetPositionQuestions(c.Request.Context(), viewer, id)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
@@ -289,28 +561,120 @@ type PublicIDResponse struct {
 
 func (h *handler) CreateInterview(c *gin.Context) {
 	candidatePublicID := c.GetString("public_id")
-	if c.GetString("role") != "candidate" {
-		c.JSON(http.StatusUnauthorized, sendResponse(-1, nil, models.ErrPermissionDenied))
-		return
-	}
 
 	positionPublicID := c.Param("position_public_id")
 
-	publicID, err := h.service.PositionService.CreateInterview(positionPublicID, candidatePublicID)
+	publicID, created, err := h.service.PositionService.CreateInterview(c.Request.Context(), positionPublicID, candidatePublicID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		switch {
+		case errors.Is(err, models.ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+		case errors.Is(err, models.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrUserNotFound))
+		case errors.Is(err, models.ErrPositionNotOpen):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrPositionNotOpen))
+		case errors.Is(err, models.ErrAttemptsExceeded):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrAttemptsExceeded))
+		default:
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, sendResponse(0, PublicIDResponse{
+	// repeating the request for an active interview returns it unchanged
+	status := http.StatusCreated
+	if !created {
+		status = http.StatusOK
+	}
+	c.JSON(status, sendResponse(0, PublicIDResponse{
 		PublicID: publicID,
 	}, nil))
 }
 
+// ReorderQuestions sets the interview order of a position's questions. The
+// body must list every question of the position once.
+func (h *handler) ReorderQuestions(c *gin.Context) {
+	id := c.Param("position_public_id")
+	req := &questionOrderReq{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when reordering questions: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+
+	res, err := h.service.PositionService.ReorderQuestions(c.Request.Context(), id, req.QuestionPublicIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		case errors.Is(err, models.ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+		default:
+			h.log(c).Errorf("failed to reorder questions of position %s: %v", id, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, Questions{
+		PositionPublicID: id,
+		Questions:        res,
+	}, nil))
+}
+
+// GetRubric returns the question weights, maximum scores and pass threshold
+// interviews of the position are scored with.
+func (h *handler) GetRubric(c *gin.Context) {
+	id := c.Param("position_public_id")
+	res, err := h.service.PositionService.GetRubric(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+		default:
+			h.log(c).Errorf("failed to get rubric of position %s: %v", id, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+// UpdateRubric changes the rubric of the position and rescores its evaluated
+// interviews. Questions left out of the body keep their weights.
+func (h *handler) UpdateRubric(c *gin.Context) {
+	id := c.Param("position_public_id")
+	req := &models.Rubric{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when updating rubric: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+
+	res, err := h.service.PositionService.UpdateRubric(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		case errors.Is(err, models.ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+		case errors.Is(err, models.ErrQuestionNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrQuestionNotFound))
+		default:
+			h.log(c).Errorf("failed to update rubric of position %s: %v", id, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
 func (h *handler) DeleteQuestion(c *gin.Context) {
 	publicID := c.Param("question_public_id")
 
-	err := h.service.PositionService.DeleteQuestion(publicID)
+	err := h.service.PositionService.DeleteQuestion(c.Request.Context(), publicID)
 
 	if err != nil {
 		if errors.Is(err, models.ErrQuestionNotFound) {
@@ -328,12 +692,12 @@ func (h *handler) UpdateQuestion(c *gin.Context) {
 	publicID := c.Param("question_public_id")
 	req := &models.Question{}
 	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
-		h.logger.Errorf("Failed to parse request body when deleting skills from position: %s\n", err.Error())
+		h.log(c).Errorf("Failed to parse request body when deleting skills from position: %s\n", err.Error())
 		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
 		return
 	}
 	req.PublicID = publicID
-	res, err := h.service.PositionService.UpdateQuestion(req)
+	res, err := h.service.PositionService.UpdateQuestion(c.Request.Context(), req)
 	if err != nil {
 		if errors.Is(err, models.ErrQuestionNotFound) {
 			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrQuestionNotFound))
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// Decide sets the recruiter decision on a finished interview.
+func (h *handler) Decide(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	req := &models.Decision{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when deciding on interview: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+	req.InterviewPublicID = publicID
+	req.AuthorPublicID = c.GetString("public_id")
+
+	if err := h.service.InterviewService.Decide(c.Request.Context(), req); err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		case errors.Is(err, models.ErrInterviewNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+		case errors.Is(err, models.ErrInterviewNotDone):
+			c.JSON(http.StatusConflict, sendResponse(-1, nil, models.ErrInterviewNotDone))
+		default:
+			h.log(c).Errorf("failed to decide on interview %s: %v", publicID, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, req, nil))
+}
+
+// GetDecisions returns the history of decisions on an interview.
+func (h *handler) GetDecisions(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	res, err := h.service.InterviewService.GetDecisions(c.Request.Context(), publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrInterviewNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to get decisions of interview %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+func (h *handler) AddNote(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	req := &models.Note{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when adding note: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+	req.InterviewPublicID = publicID
+	req.AuthorPublicID = c.GetString("public_id")
+
+	if err := h.service.InterviewService.AddNote(c.Request.Context(), req); err != nil {
+		if errors.Is(err, models.ErrInterviewNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to add note to interview %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusCreated, sendResponse(0, req, nil))
+}
+
+// UpdateNote changes the body of a note. Only the author of the note may edit it.
+func (h *handler) UpdateNote(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	req := &models.Note{}
+	if err := c.ShouldBindWith(req, binding.JSON); err != nil {
+		h.log(c).Errorf("failed to parse request body when updating note: %s", err.Error())
+		c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+		return
+	}
+	req.PublicID = c.Param("note_public_id")
+	req.InterviewPublicID = publicID
+	req.AuthorPublicID = c.GetString("public_id")
+
+	if err := h.service.InterviewService.UpdateNote(c.Request.Context(), req); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoteNotFound):
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrNoteNotFound))
+		case errors.Is(err, models.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, sendResponse(-1, nil, models.ErrPermissionDenied))
+		default:
+			h.log(c).Errorf("failed to update note %s: %v", req.PublicID, err)
+			c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, req, nil))
+}
+
+func (h *handler) GetNotes(c *gin.Context) {
+	publicID := c.Param("interview_public_id")
+
+	res, err := h.service.InterviewService.GetNotes(c.Request.Context(), publicID)
+	if err != nil {
+		if errors.Is(err, models.ErrInterviewNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrInterviewNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to get notes of interview %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
+
+// parseInterviewFilter reads the decision query parameter, repeated or comma
+// separated; "none" selects interviews without a decision.
+func parseInterviewFilter(c *gin.Context) (*models.InterviewFilter, error) {
+	filter := &models.InterviewFilter{}
+	for _, value := range c.QueryArray("decision") {
+		for _, name := range strings.Split(value, ",") {
+			decision, err := models.ParseDecision(strings.TrimSpace(name), true)
+			if err != nil {
+				return nil, err
+			}
+			filter.Decisions = append(filter.Decisions, decision)
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultScoreBuckets = 10
+	maxScoreBuckets     = 100
+)
+
+// GetPositionStats returns the analytics of a position. The buckets query
+// parameter sets the number of score histogram buckets.
+func (h *handler) GetPositionStats(c *gin.Context) {
+	publicID := c.Param("position_public_id")
+
+	buckets := defaultScoreBuckets
+	if value := c.Query("buckets"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxScoreBuckets {
+			c.JSON(http.StatusBadRequest, sendResponse(-1, nil, models.ErrInvalidInput))
+			return
+		}
+		buckets = n
+	}
+
+	res, err := h.service.InterviewService.GetPositionStats(c.Request.Context(), publicID, buckets)
+	if err != nil {
+		if errors.Is(err, models.ErrPositionNotFound) {
+			c.JSON(http.StatusNotFound, sendResponse(-1, nil, models.ErrPositionNotFound))
+			return
+		}
+		h.log(c).Errorf("failed to get stats of position %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, sendResponse(-1, nil, models.ErrInternalServer))
+		return
+	}
+
+	c.JSON(http.StatusOK, sendResponse(0, res, nil))
+}
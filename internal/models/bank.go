@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// BankQuestion is a reusable question of a company. Nil fields are left
+// unchanged on update.
+type BankQuestion struct {
+	PublicID        string     `json:"public_id"`
+	CompanyPublicID string     `json:"company_public_id"`
+	Name            *string    `json:"name"`
+	Type            *string    `json:"type" binding:"omitempty,oneof=tech soft"`
+	Tags            []string   `json:"tags"`
+	ReadDuration    *int       `json:"read_duration" binding:"omitempty,min=0"`
+	AnswerDuration  *int       `json:"answer_duration" binding:"omitempty,min=0"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+// BankFilter narrows a company's question bank. Zero values disable a filter;
+// a question must carry all of Tags.
+type BankFilter struct {
+	Search string
+	Type   string
+	Tags   []string
+}
+
+// BankAttachment adds a bank question to a position. Unless Copy is set the
+// position question stays linked and follows later edits of the bank question.
+type BankAttachment struct {
+	PublicID string `json:"public_id" binding:"required,uuid"`
+	Copy     bool   `json:"copy"`
+}
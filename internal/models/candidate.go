@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// CandidateInterview is an interview as shown to the candidate who took it.
+// Result is only set once the interview is evaluated.
+type CandidateInterview struct {
+	PublicID   string           `json:"public_id"`
+	Status     string           `json:"status"`
+	Position   *Position        `json:"position,omitempty"`
+	Result     *CandidateResult `json:"result,omitempty"`
+	RawResult  []byte           `json:"-"`
+	RawScoring []byte           `json:"-"`
+	CreatedAt  *time.Time       `json:"created_at,omitempty"`
+	StartedAt  *time.Time       `json:"started_at,omitempty"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+	ExpiresAt  *time.Time       `json:"expires_at,omitempty"`
+}
+
+// CandidateResult is the part of a Result a candidate may see, without the
+// evaluation texts and emotion analysis meant for recruiters. Score is the
+// normalized score out of 100 the interview is ranked and passed by. Questions
+// are left out unless the position shows the score breakdown.
+type CandidateResult struct {
+	Score     float64                   `json:"score"`
+	Passed    bool                      `json:"passed"`
+	Questions []CandidateQuestionResult `json:"questions,omitempty"`
+}
+
+type CandidateQuestionResult struct {
+	QuestionPublicID string `json:"question_public_id,omitempty"`
+	Question         string `json:"question"`
+	QuestionType     string `json:"question_type"`
+	Score            int    `json:"score"`
+	VideoLink        string `json:"video_link"`
+}
+
+// Application summarizes the interviews of a candidate at one position.
+// MaxAttempts is 0 when attempts are unlimited.
+type Application struct {
+	Position          *Position  `json:"position"`
+	Attempts          int        `json:"attempts"`
+	MaxAttempts       int        `json:"max_attempts"`
+	InterviewPublicID string     `json:"interview_public_id"`
+	Status            string     `json:"status"`
+	LastActivityAt    *time.Time `json:"last_activity_at,omitempty"`
+}
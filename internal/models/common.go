@@ -3,6 +3,37 @@ package models
 const (
 	DefaultPageNum        = 1
 	DefaultPageSize       = 10
-	DefaultTechnologyType = 0
-	DefaultSortType       = 0
+	DefaultTechnologyType = TechnologyMatchAny
+	DefaultSortType       = SortByCreatedAt
+)
+
+// Pagination selects a page either by number (offset mode) or by an opaque
+// cursor taken from a previous PageInfo (keyset mode).
+type Pagination struct {
+	PageNum   int
+	PageSize  int
+	Cursor    string
+	WithCount bool
+}
+
+// PageInfo describes a returned page. Count is only set when it was requested.
+type PageInfo struct {
+	Count      *int   `json:"count,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// How the skills of a position listing filter are matched.
+const (
+	TechnologyMatchAny = iota
+	TechnologyMatchAll
+)
+
+// Sort orders supported by the position listings.
+const (
+	SortByCreatedAt = iota
+	SortByName
+	SortByInterviewCount
+	SortByAverageScore
+	SortByRelevance
 )
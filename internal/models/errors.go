@@ -3,12 +3,25 @@ package models
 import "errors"
 
 var (
-	ErrInvalidInput        = errors.New("INVALID_INPUT")
-	ErrInternalServer      = errors.New("INTERNAL_SERVER_ERROR")
-	ErrCompanyDoesntExists = errors.New("COMPANY_DOES_NOT_EXIST")
-	ErrUsernameExists      = errors.New("USERNAME_EXISTS")
-	ErrUserNotFound        = errors.New("USER_NOT_FOUND")
-	ErrPermissionDenied    = errors.New("PERMISSION_DENIED")
-	ErrPositionNotFound    = errors.New("POSITION_NOT_FOUND")
-	ErrQuestionNotFound    = errors.New("QUESTION_NOT_FOUND")
+	ErrInvalidInput         = errors.New("INVALID_INPUT")
+	ErrInternalServer       = errors.New("INTERNAL_SERVER_ERROR")
+	ErrCompanyDoesntExists  = errors.New("COMPANY_DOES_NOT_EXIST")
+	ErrUsernameExists       = errors.New("USERNAME_EXISTS")
+	ErrUserNotFound         = errors.New("USER_NOT_FOUND")
+	ErrPermissionDenied     = errors.New("PERMISSION_DENIED")
+	ErrPositionNotFound     = errors.New("POSITION_NOT_FOUND")
+	ErrQuestionNotFound     = errors.New("QUESTION_NOT_FOUND")
+	ErrSkillNotFound        = errors.New("SKILL_NOT_FOUND")
+	ErrInvalidCursor        = errors.New("INVALID_CURSOR")
+	ErrInvalidTransition    = errors.New("INVALID_STATUS_TRANSITION")
+	ErrPositionNotOpen      = errors.New("POSITION_NOT_OPEN")
+	ErrInterviewNotFound    = errors.New("INTERVIEW_NOT_FOUND")
+	ErrInterviewExpired     = errors.New("INTERVIEW_EXPIRED")
+	ErrAttemptsExceeded     = errors.New("INTERVIEW_ATTEMPTS_EXCEEDED")
+	ErrInvalidResult        = errors.New("INVALID_RESULT")
+	ErrInterviewNotStarted  = errors.New("INTERVIEW_NOT_IN_PROGRESS")
+	ErrInterviewNotDone     = errors.New("INTERVIEW_NOT_FINISHED")
+	ErrRetakesExceeded      = errors.New("ANSWER_RETAKES_EXCEEDED")
+	ErrNoteNotFound         = errors.New("NOTE_NOT_FOUND")
+	ErrBankQuestionNotFound = errors.New("BANK_QUESTION_NOT_FOUND")
 )
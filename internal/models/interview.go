@@ -1,26 +1,208 @@
 package models
 
+import (
+	"strings"
+	"time"
+)
+
+// Interview statuses. An interview is scheduled when created, in progress once the
+// candidate starts it and submitted when they finish; evaluated once results are stored.
+// Scheduled and in progress interviews expire when their deadline passes.
+const (
+	InterviewStatusScheduled  = "scheduled"
+	InterviewStatusInProgress = "in_progress"
+	InterviewStatusSubmitted  = "submitted"
+	InterviewStatusEvaluated  = "evaluated"
+	InterviewStatusExpired    = "expired"
+	InterviewStatusCancelled  = "cancelled"
+)
+
+// ParseInterviewStatus validates an API interview status name.
+func ParseInterviewStatus(name string) (string, error) {
+	switch name {
+	case InterviewStatusScheduled, InterviewStatusInProgress, InterviewStatusSubmitted,
+		InterviewStatusEvaluated, InterviewStatusExpired, InterviewStatusCancelled:
+		return name, nil
+	}
+	return "", ErrInvalidInput
+}
+
 type InterviewResults struct {
 	PublicID          string
 	Result            []byte
 	CandidatePublicID string `json:"candidate_public_id"`
+	Status            string
+	Decision          string
+	Scoring           []byte
 }
 
 type Interview struct {
-	PublicID          string `json:"public_id"`
-	Result            Result `json:"result"`
-	CandidatePublicID string `json:"candidate_public_id"`
+	PublicID          string     `json:"public_id"`
+	Result            *Result    `json:"result,omitempty"`
+	Scoring           *Scoring   `json:"scoring,omitempty"`
+	CandidatePublicID string     `json:"candidate_public_id"`
+	PositionPublicID  string     `json:"position_public_id,omitempty"`
+	Status            string     `json:"status,omitempty"`
+	Decision          string     `json:"decision,omitempty"`
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	FinishedAt        *time.Time `json:"finished_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+}
+
+// InterviewDetail is an interview with its position and the questions
+// snapshotted for it when it was created.
+type InterviewDetail struct {
+	Interview
+	Position  *Position   `json:"position"`
+	Questions []*Question `json:"questions"`
+}
+
+// LeaderboardEntry is the best attempt of a candidate at a position, ranked
+// against the best attempts of the other candidates. Scores are weighted by the
+// rubric and normalized to 0-100, like the scoring of the interview.
+type LeaderboardEntry struct {
+	Rank              int        `json:"rank"`
+	CandidatePublicID string     `json:"candidate_public_id"`
+	InterviewPublicID string     `json:"interview_public_id"`
+	Status            string     `json:"status"`
+	Score             *float64   `json:"score"`
+	Passed            *bool      `json:"passed"`
+	TechScore         *float64   `json:"tech_score"`
+	SoftScore         *float64   `json:"soft_score"`
+	Percentile        float64    `json:"percentile"`
+	Attempts          int        `json:"attempts"`
+	FinishedAt        *time.Time `json:"finished_at,omitempty"`
+}
+
+// LeaderboardFilter narrows a leaderboard. Statuses select the interviews that
+// are ranked; MinScore, out of 100, only hides entries below it and leaves ranks unchanged.
+type LeaderboardFilter struct {
+	Statuses []string
+	MinScore *float64
+}
+
+// PositionStats summarizes the interviews of a position. Scores and durations
+// are nil while no interview has them. Scores are normalized to 0-100, PassRate
+// is the share of scored interviews that passed and durations are in seconds.
+type PositionStats struct {
+	InterviewCount           int             `json:"interview_count"`
+	StatusCounts             map[string]int  `json:"status_counts"`
+	CompletionRate           float64         `json:"completion_rate"`
+	AverageScore             *float64        `json:"average_score"`
+	MedianScore              *float64        `json:"median_score"`
+	PassRate                 *float64        `json:"pass_rate"`
+	ScoreHistogram           []ScoreBucket   `json:"score_histogram"`
+	Questions                []QuestionStats `json:"questions"`
+	AverageInterviewDuration *float64        `json:"average_interview_duration"`
+	AverageAnswerDuration    *float64        `json:"average_answer_duration"`
+	DominantEmotions         []EmotionShare  `json:"dominant_emotions"`
+}
+
+// ScoreBucket counts the normalized scores in [From, To), the last bucket including 100.
+type ScoreBucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+type QuestionStats struct {
+	QuestionPublicID string   `json:"question_public_id,omitempty"`
+	Question         string   `json:"question"`
+	Answers          int      `json:"answers"`
+	MeanScore        *float64 `json:"mean_score"`
+	MedianScore      *float64 `json:"median_score"`
 }
 
+// EmotionShare is the time spent in an emotion and its percentage of all emotion time.
+type EmotionShare struct {
+	Emotion  string  `json:"emotion"`
+	Duration float64 `json:"duration"`
+	Share    float64 `json:"share"`
+}
+
+// EmotionSegment is a span of an answer, in seconds from its start, showing one emotion.
+type EmotionSegment struct {
+	Emotion string  `json:"emotion"`
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+}
+
+// QuestionEmotions is the emotion timeline of one question. Timeline is only
+// set for a single interview.
+type QuestionEmotions struct {
+	QuestionPublicID string           `json:"question_public_id,omitempty"`
+	Question         string           `json:"question"`
+	Timeline         []EmotionSegment `json:"timeline,omitempty"`
+	Shares           []EmotionShare   `json:"shares"`
+}
+
+type InterviewEmotions struct {
+	InterviewPublicID string             `json:"interview_public_id"`
+	Questions         []QuestionEmotions `json:"questions"`
+	Shares            []EmotionShare     `json:"shares"`
+}
+
+type PositionEmotions struct {
+	PositionPublicID string             `json:"position_public_id"`
+	Interviews       int                `json:"interviews"`
+	Questions        []QuestionEmotions `json:"questions"`
+	Shares           []EmotionShare     `json:"shares"`
+}
+
+// Answer is the recording of a candidate answering one interview question.
+type Answer struct {
+	PublicID          string     `json:"public_id"`
+	InterviewPublicID string     `json:"interview_public_id"`
+	QuestionPublicID  string     `json:"question_public_id" binding:"required,uuid"`
+	VideoPublicID     *string    `json:"video_public_id,omitempty"`
+	VideoLink         string     `json:"video_link" binding:"required"`
+	Transcript        string     `json:"transcript"`
+	Duration          *float64   `json:"duration,omitempty" binding:"omitempty,gte=0"` // seconds of recording
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+}
+
+// Question types of a QuestionResult.
+const (
+	QuestionTypeTech = "tech"
+	QuestionTypeSoft = "soft"
+)
+
 type QuestionResult struct {
-	Question       string          `json:"question"`
-	QuestionType   string          `json:"question_type"`
-	Evaluation     string          `json:"evaluation"`
-	Score          int             `json:"score"`
-	VideoLink      string          `json:"video_link"`
-	EmotionResults []EmotionResult `json:"emotion_results"`
-	Answer         string          `json:"answer"`
-	Emotion        string          `json:"emotion"`
+	QuestionPublicID string          `json:"question_public_id,omitempty"`
+	Question         string          `json:"question"`
+	QuestionType     string          `json:"question_type"`
+	Evaluation       string          `json:"evaluation"`
+	Score            int             `json:"score"`
+	VideoLink        string          `json:"video_link"`
+	EmotionResults   []EmotionResult `json:"emotion_results"`
+	Answer           string          `json:"answer"`
+	Emotion          string          `json:"emotion"`
+}
+
+// EmotionAliases maps the labels emitted by the evaluation models to one name
+// per emotion. The position stats query normalizes labels with it too.
+var EmotionAliases = map[string]string{
+	"happy":      "happiness",
+	"joy":        "happiness",
+	"sad":        "sadness",
+	"angry":      "anger",
+	"fearful":    "fear",
+	"scared":     "fear",
+	"surprised":  "surprise",
+	"disgusted":  "disgust",
+	"calm":       "neutral",
+	"confident":  "confidence",
+	"determined": "determination",
+}
+
+// NormalizeEmotion lower-cases and trims an emotion label and resolves its alias.
+func NormalizeEmotion(label string) string {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if alias, ok := EmotionAliases[label]; ok {
+		return alias
+	}
+	return label
 }
 
 type EmotionResult struct {
@@ -1,13 +1,92 @@
 package models
 
+import "time"
+
+// Position lifecycle states as stored in positions.status.
+const (
+	PositionStatusDraft = iota
+	PositionStatusOpen
+	PositionStatusPaused
+	PositionStatusClosed
+	PositionStatusArchived
+)
+
+var positionStatusNames = map[int]string{
+	PositionStatusDraft:    "draft",
+	PositionStatusOpen:     "open",
+	PositionStatusPaused:   "paused",
+	PositionStatusClosed:   "closed",
+	PositionStatusArchived: "archived",
+}
+
+// PositionStatusName returns the API name of a position status.
+func PositionStatusName(status int) (string, bool) {
+	name, ok := positionStatusNames[status]
+	return name, ok
+}
+
+// ParsePositionStatus converts an API status name into its stored value.
+func ParsePositionStatus(name string) (int, error) {
+	for status, n := range positionStatusNames {
+		if n == name {
+			return status, nil
+		}
+	}
+	return 0, ErrInvalidInput
+}
+
 type Position struct {
-	PublicID          *string   `json:"public_id"`
-	Name              *string   `json:"name"`
-	Status            *int      `json:"status"`
-	Skills            []*string `json:"skills"`
-	Company           *Company  `json:"company,omitempty"`
-	RecruiterPublicID *string   `json:"recruiter_public_id,omitempty"`
-	Description       *string   `json:"description"`
+	PublicID          *string    `json:"public_id"`
+	Name              *string    `json:"name"`
+	Status            *int       `json:"status"`
+	StatusChangedAt   *time.Time `json:"status_changed_at,omitempty"`
+	Skills            []*string  `json:"skills"`
+	Company           *Company   `json:"company,omitempty"`
+	RecruiterPublicID *string    `json:"recruiter_public_id,omitempty"`
+	Description       *string    `json:"description"`
+	// MaxAttempts limits the interviews a candidate may take; 0 is unlimited and nil uses the default.
+	MaxAttempts *int `json:"max_attempts,omitempty" binding:"omitempty,min=0"`
+	// ResultVisibility sets what candidates see of their evaluated interviews.
+	ResultVisibility *string    `json:"result_visibility,omitempty" binding:"omitempty,oneof=hidden score breakdown"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	InterviewCount   *int       `json:"interview_count,omitempty"`
+	AverageScore     *float64   `json:"average_score,omitempty"`
+	// Rank and Highlights are only set for full-text search results.
+	Rank       *float64            `json:"rank,omitempty"`
+	Highlights *PositionHighlights `json:"highlights,omitempty"`
+}
+
+// PositionWithQuestions is a position with its questions, the unit of the
+// positions export and import.
+type PositionWithQuestions struct {
+	Position
+	Questions []*Question `json:"questions"`
+}
+
+// PositionHighlights holds search matches wrapped in <mark> tags.
+type PositionHighlights struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// PositionFilter narrows and orders the position listings. Zero values disable a filter.
+type PositionFilter struct {
+	Search            string
+	Skills            []string
+	SkillsMatch       int
+	CompanyPublicID   string
+	RecruiterPublicID string
+	Status            *int
+	CreatedFrom       *time.Time
+	CreatedTo         *time.Time
+	Sort              int
+	Ascending         bool
+}
+
+// PositionOwner identifies who may manage a position.
+type PositionOwner struct {
+	RecruiterPublicID string
+	CompanyPublicID   string
 }
 
 type Company struct {
@@ -25,4 +104,18 @@ type Question struct {
 	PositionID       int    `json:"-"`
 	ReadDuration     int    `json:"read_duration"`
 	AnswerDuration   int    `json:"answer_duration"`
+	Type             string `json:"type,omitempty" binding:"omitempty,oneof=tech soft"`
+	// Ordinal is the 1-based place of the question in its position's interview.
+	Ordinal    int    `json:"ordinal,omitempty"`
+	Difficulty string `json:"difficulty,omitempty" binding:"omitempty,oneof=easy medium hard"`
+	// Rubric describes what a good answer covers.
+	Rubric string `json:"rubric,omitempty"`
+	// MaxRetakes is how many times the candidate may record the answer again.
+	MaxRetakes *int `json:"max_retakes,omitempty" binding:"omitempty,min=0"`
+	// Skills are the skills the question assesses; nil leaves them unchanged on update.
+	Skills []string `json:"skills,omitempty"`
+	// BankQuestionPublicID is set for questions taken from the question bank;
+	// Linked ones follow edits of their bank question.
+	BankQuestionPublicID *string `json:"bank_question_public_id,omitempty"`
+	Linked               bool    `json:"linked,omitempty"`
 }
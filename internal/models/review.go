@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Recruiter decisions on a finished interview. DecisionNone selects the
+// interviews without a decision when filtering.
+const (
+	DecisionShortlisted = "shortlisted"
+	DecisionRejected    = "rejected"
+	DecisionOnHold      = "on_hold"
+	DecisionHired       = "hired"
+	DecisionNone        = "none"
+)
+
+// ParseDecision validates an API decision name; DecisionNone is accepted only
+// when filtering.
+func ParseDecision(name string, filtering bool) (string, error) {
+	switch name {
+	case DecisionShortlisted, DecisionRejected, DecisionOnHold, DecisionHired:
+		return name, nil
+	case DecisionNone:
+		if filtering {
+			return name, nil
+		}
+	}
+	return "", ErrInvalidInput
+}
+
+// InterviewFilter narrows the interviews listing of a position. Zero values disable a filter.
+type InterviewFilter struct {
+	Decisions []string
+}
+
+// Decision is one change of the recruiter decision on an interview.
+type Decision struct {
+	InterviewPublicID string    `json:"interview_public_id"`
+	Decision          string    `json:"decision" binding:"required"`
+	PreviousDecision  *string   `json:"previous_decision"`
+	AuthorPublicID    string    `json:"author_public_id"`
+	Comment           string    `json:"comment,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// Note is a free-text remark of a recruiter on an interview.
+type Note struct {
+	PublicID          string    `json:"public_id"`
+	InterviewPublicID string    `json:"interview_public_id"`
+	AuthorPublicID    string    `json:"author_public_id"`
+	Body              string    `json:"body" binding:"required,max=10000"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// RubricQuestion is how one question counts towards the total score. Nil
+// fields are left unchanged on update.
+type RubricQuestion struct {
+	QuestionPublicID string   `json:"question_public_id" binding:"required,uuid"`
+	Question         string   `json:"question,omitempty"`
+	Weight           *float64 `json:"weight" binding:"omitempty,gt=0"`
+	MaxScore         *int     `json:"max_score" binding:"omitempty,min=1"`
+	Skills           []string `json:"skills,omitempty"`
+}
+
+// Rubric weighs the questions of a position. PassThreshold is out of 100.
+type Rubric struct {
+	PassThreshold *float64         `json:"pass_threshold" binding:"omitempty,min=0,max=100"`
+	Questions     []RubricQuestion `json:"questions" binding:"dive"`
+}
+
+// Scoring is the weighted score of an interview normalized to 0-100, overall,
+// per question type and per skill of the questions. Type scores are nil when
+// the interview has no question of the type.
+type Scoring struct {
+	Score         float64      `json:"score"`
+	Passed        bool         `json:"passed"`
+	PassThreshold float64      `json:"pass_threshold"`
+	TechScore     *float64     `json:"tech_score"`
+	SoftScore     *float64     `json:"soft_score"`
+	Skills        []SkillScore `json:"skills"`
+	ScoredAt      time.Time    `json:"scored_at"`
+}
+
+type SkillScore struct {
+	Skill     string  `json:"skill"`
+	Score     float64 `json:"score"`
+	Questions int     `json:"questions"`
+}
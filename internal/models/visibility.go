@@ -0,0 +1,96 @@
+package models
+
+// What candidates of a position see of their evaluated interviews: nothing,
+// the total score, or the score of every question.
+const (
+	ResultVisibilityHidden    = "hidden"
+	ResultVisibilityScore     = "score"
+	ResultVisibilityBreakdown = "breakdown"
+)
+
+// Views of an interview, from the full recruiter view to the public one.
+const (
+	ViewRecruiter = "recruiter"
+	ViewCandidate = "candidate"
+	ViewPublic    = "public"
+)
+
+// Viewer is the caller an interview is shown to. PublicID is empty for
+// anonymous callers.
+type Viewer struct {
+	View     string
+	PublicID string
+}
+
+// InterviewView is an interview shaped for one view: *Interview for
+// recruiters, *CandidateInterview for the candidate who took it and
+// *PublicInterview for everyone else.
+type InterviewView interface {
+	interviewView()
+}
+
+// PublicInterview is what anonymous callers and other candidates see of an interview.
+type PublicInterview struct {
+	PublicID string `json:"public_id"`
+	Status   string `json:"status"`
+}
+
+func (*Interview) interviewView()          {}
+func (*CandidateInterview) interviewView() {}
+func (*PublicInterview) interviewView()    {}
+
+// CandidateResultView reduces a result and its scoring to what a candidate may
+// see under the given visibility. It returns nil when results are hidden or
+// not scored yet.
+func CandidateResultView(result *Result, scoring *Scoring, visibility string) *CandidateResult {
+	if result == nil || scoring == nil {
+		return nil
+	}
+	switch visibility {
+	case ResultVisibilityScore:
+		return &CandidateResult{Score: scoring.Score, Passed: scoring.Passed}
+	case ResultVisibilityBreakdown:
+		res := &CandidateResult{
+			Score:     scoring.Score,
+			Passed:    scoring.Passed,
+			Questions: make([]CandidateQuestionResult, 0, len(result.Questions)),
+		}
+		for _, q := range result.Questions {
+			res.Questions = append(res.Questions, CandidateQuestionResult{
+				QuestionPublicID: q.QuestionPublicID,
+				Question:         q.Question,
+				QuestionType:     q.QuestionType,
+				Score:            q.Score,
+				VideoLink:        q.VideoLink,
+			})
+		}
+		return res
+	}
+	return nil
+}
+
+// QuestionsView reduces questions to what the given view may see. The rubric
+// describes the expected answer, so only recruiters see it.
+func QuestionsView(questions []*Question, view string) []*Question {
+	if view == ViewRecruiter {
+		return questions
+	}
+	res := make([]*Question, 0, len(questions))
+	for _, q := range questions {
+		q := *q
+		q.Rubric = ""
+		res = append(res, &q)
+	}
+	return res
+}
+
+// InterviewForView reduces an interview to what the given view may see. The
+// recruiter's decision is shown to recruiters only.
+func InterviewForView(interview *Interview, view string) *Interview {
+	if view == ViewRecruiter {
+		return interview
+	}
+	res := *interview
+	res.Decision = ""
+	return &res
+}
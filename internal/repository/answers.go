@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/jackc/pgx/v4"
+)
+
+// SaveAnswer stores the video of an answer to one of the interview's snapshotted
+// questions. Answering a question again replaces the previous answer and points
+// its video at the new recording, as long as the question allows another retake.
+func (r *interviewRepository) SaveAnswer(ctx context.Context, answer *models.Answer) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// the interview row lock serializes answers, so two first answers to a
+	// question can't both create a video and two retakes can't both pass the limit
+	var interviewID, maxRetakes int
+	query := `SELECT i.id, iq.max_retakes
+		FROM interviews i
+		INNER JOIN interview_questions iq ON iq.interview_id = i.id
+		WHERE i.public_id = $1 AND iq.question_public_id = $2
+		FOR UPDATE OF i`
+	err = tx.QueryRow(ctx, query, answer.InterviewPublicID, answer.QuestionPublicID).Scan(&interviewID, &maxRetakes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrQuestionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while retrieving interview question: %v", err)
+		return err
+	}
+
+	var videoPublicID *string
+	var recordings int
+	query = `SELECT video_public_id, recordings FROM answers WHERE interview_id = $1 AND question_public_id = $2`
+	err = tx.QueryRow(ctx, query, interviewID, answer.QuestionPublicID).Scan(&videoPublicID, &recordings)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.log(ctx).Errorf("Error occurred while retrieving previous answer: %v", err)
+		return err
+	}
+	// the first recording is not a retake
+	if recordings > maxRetakes {
+		return models.ErrRetakesExceeded
+	}
+	if videoPublicID != nil {
+		tag, err := tx.Exec(ctx, `UPDATE videos SET path = $2 WHERE public_id = $1`, *videoPublicID, answer.VideoLink)
+		if err != nil {
+			r.log(ctx).Errorf("Error occurred while replacing answer video: %v", err)
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			videoPublicID = nil
+		}
+	}
+	if videoPublicID == nil {
+		query = `INSERT INTO videos (interviews_public_id, path) VALUES ($1, $2) RETURNING public_id`
+		if err := tx.QueryRow(ctx, query, answer.InterviewPublicID, answer.VideoLink).Scan(&videoPublicID); err != nil {
+			r.log(ctx).Errorf("Error occurred while storing answer video: %v", err)
+			return err
+		}
+	}
+	answer.VideoPublicID = videoPublicID
+
+	query = `INSERT INTO answers (interview_id, question_public_id, video_public_id, transcript, duration)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		ON CONFLICT (interview_id, question_public_id) DO UPDATE
+		SET video_public_id = EXCLUDED.video_public_id, transcript = EXCLUDED.transcript,
+			duration = EXCLUDED.duration, recordings = answers.recordings + 1, created_at = now()
+		RETURNING public_id, created_at`
+	err = tx.QueryRow(ctx, query, interviewID, answer.QuestionPublicID, videoPublicID, answer.Transcript, answer.Duration).Scan(&answer.PublicID, &answer.CreatedAt)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while storing answer: %v", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetAnswers returns the answers of the given interviews in question order.
+func (r *interviewRepository) GetAnswers(ctx context.Context, interviewPublicIDs []string) ([]*models.Answer, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `SELECT a.public_id, i.public_id, a.question_public_id, a.video_public_id, COALESCE(v.path, ''), COALESCE(a.transcript, ''), a.duration, a.created_at
+		FROM answers a
+		INNER JOIN interviews i ON i.id = a.interview_id
+		LEFT JOIN interview_questions iq ON iq.interview_id = a.interview_id AND iq.question_public_id = a.question_public_id
+		LEFT JOIN videos v ON v.public_id = a.video_public_id
+		WHERE i.public_id = ANY($1::uuid[])
+		ORDER BY i.id, iq.ordinal`
+
+	rows, err := r.db.Query(ctx, query, interviewPublicIDs)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while querying answers: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := []*models.Answer{}
+	for rows.Next() {
+		answer := &models.Answer{}
+		err := rows.Scan(
+			&answer.PublicID,
+			&answer.InterviewPublicID,
+			&answer.QuestionPublicID,
+			&answer.VideoPublicID,
+			&answer.VideoLink,
+			&answer.Transcript,
+			&answer.Duration,
+			&answer.CreatedAt,
+		)
+		if err != nil {
+			r.log(ctx).Errorf("Error scanning answer row: %v", err)
+			return nil, err
+		}
+		answers = append(answers, answer)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over answer rows: %v", err)
+		return nil, err
+	}
+	return answers, nil
+}
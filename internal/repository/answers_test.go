@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TestSaveAnswerRetakes checks that an answer can be recorded once plus the
+// retakes its snapshotted question allows, and no more.
+func TestSaveAnswerRetakes(t *testing.T) {
+	db, _ := openTestDB(t)
+	recruiterPublicID := setupRecruiter(t, db)
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+	positions := &positionRepository{db: db, cfg: testDBConf, logger: logger}
+	interviews := &interviewRepository{db: db, cfg: testDBConf, logger: logger}
+
+	candidatePublicID := uuid.NewString()
+	setup := []string{
+		`INSERT INTO users (public_id, first_name) VALUES ($1, 'Test')`,
+		`INSERT INTO candidates (public_id) VALUES ($1)`,
+	}
+	for _, query := range setup {
+		if _, err := db.Exec(ctx, query, candidatePublicID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		// interviews outlive their candidate, answers and videos cascade from them
+		db.Exec(context.Background(), `DELETE FROM interviews WHERE id IN (
+			SELECT ui.interview_id FROM user_interviews ui
+			INNER JOIN candidates c ON c.id = ui.candidate_id
+			WHERE c.public_id = $1)`, candidatePublicID)
+		db.Exec(context.Background(), `DELETE FROM users WHERE public_id = $1`, candidatePublicID)
+	})
+
+	name := "Retakes"
+	status := models.PositionStatusOpen
+	positionPublicID, err := positions.CreatePosition(ctx, &models.Position{
+		Name:              &name,
+		Status:            &status,
+		RecruiterPublicID: &recruiterPublicID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxRetakes := map[string]int{"no retakes": 0, "two retakes": 2}
+	questions := make([]*models.Question, 0, len(maxRetakes))
+	for name, retakes := range maxRetakes {
+		retakes := retakes
+		questions = append(questions, &models.Question{Name: name, AnswerDuration: 60, MaxRetakes: &retakes})
+	}
+	if questions, err = positions.AddQuestionsToPosition(ctx, positionPublicID, questions); err != nil {
+		t.Fatal(err)
+	}
+	interviewPublicID, _, err := positions.CreateInterview(ctx, positionPublicID, candidatePublicID, time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, q := range questions {
+		recordings := maxRetakes[q.Name] + 1
+		for i := 1; i <= recordings+1; i++ {
+			err := interviews.SaveAnswer(ctx, &models.Answer{
+				InterviewPublicID: interviewPublicID,
+				QuestionPublicID:  q.PublicID,
+				VideoLink:         fmt.Sprintf("video-%d", i),
+			})
+			switch {
+			case i <= recordings && err != nil:
+				t.Fatalf("%s: recording %d: %v", q.Name, i, err)
+			case i > recordings && !errors.Is(err, models.ErrRetakesExceeded):
+				t.Fatalf("%s: recording %d: got %v, want %v", q.Name, i, err, models.ErrRetakesExceeded)
+			}
+		}
+	}
+}
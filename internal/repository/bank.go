@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+)
+
+type questionBankRepository struct {
+	db     *pgxpool.Pool
+	cfg    *config.DBConf
+	logger *zap.SugaredLogger
+}
+
+func NewQuestionBankRepository(db *pgxpool.Pool, cfg *config.DBConf, logger *zap.SugaredLogger) QuestionBankRepository {
+	return &questionBankRepository{
+		db:     db,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *questionBankRepository) log(ctx context.Context) *zap.SugaredLogger {
+	return requestid.Logger(ctx, r.logger)
+}
+
+const bankQuestionColumns = `b.public_id, b.company_public_id, b.name, b.question_type, b.tags,
+	b.read_duration, b.answer_duration, b.created_at, b.updated_at`
+
+func scanBankQuestion(row pgx.Row, extra ...interface{}) (*models.BankQuestion, error) {
+	q := &models.BankQuestion{}
+	dest := []interface{}{
+		&q.PublicID,
+		&q.CompanyPublicID,
+		&q.Name,
+		&q.Type,
+		&q.Tags,
+		&q.ReadDuration,
+		&q.AnswerDuration,
+		&q.CreatedAt,
+		&q.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func (r *questionBankRepository) CreateBankQuestion(ctx context.Context, q *models.BankQuestion) (*models.BankQuestion, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `INSERT INTO bank_questions AS b (company_public_id, name, question_type, tags, read_duration, answer_duration)
+		SELECT public_id, $2, $3, $4, COALESCE($5, 0), COALESCE($6, 0) FROM companies WHERE public_id = $1
+		RETURNING ` + bankQuestionColumns
+	res, err := scanBankQuestion(r.db.QueryRow(ctx, query, q.CompanyPublicID, q.Name, q.Type, q.Tags, q.ReadDuration, q.AnswerDuration))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrCompanyDoesntExists
+		}
+		r.log(ctx).Errorf("Error occurred while creating bank question: %v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetBankQuestions returns a page of a company's question bank, newest first,
+// with the number of questions matching the filter.
+func (r *questionBankRepository) GetBankQuestions(ctx context.Context, companyPublicID string, filter *models.BankFilter, page *models.Pagination) ([]*models.BankQuestion, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tags := filter.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	const where = `WHERE b.company_public_id = $1
+			AND ($2 = '' OR b.name ILIKE '%' || $2 || '%')
+			AND ($3 = '' OR b.question_type = $3)
+			AND b.tags @> $4::text[]`
+
+	var count int
+	query := `SELECT COUNT(*) FROM bank_questions b ` + where
+	if err := r.db.QueryRow(ctx, query, companyPublicID, filter.Search, filter.Type, tags).Scan(&count); err != nil {
+		r.log(ctx).Errorf("Error occurred while counting bank questions: %v", err)
+		return nil, 0, err
+	}
+
+	query = `SELECT ` + bankQuestionColumns + `
+		FROM bank_questions b
+		` + where + `
+		ORDER BY b.id DESC
+		LIMIT ` + strconv.Itoa(page.PageSize) + ` OFFSET ` + strconv.Itoa((page.PageNum-1)*page.PageSize)
+
+	rows, err := r.db.Query(ctx, query, companyPublicID, filter.Search, filter.Type, tags)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while querying bank questions: %v", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	questions := []*models.BankQuestion{}
+	for rows.Next() {
+		q, err := scanBankQuestion(rows)
+		if err != nil {
+			r.log(ctx).Errorf("Error scanning bank question row: %v", err)
+			return nil, 0, err
+		}
+		questions = append(questions, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over bank question rows: %v", err)
+		return nil, 0, err
+	}
+	return questions, count, nil
+}
+
+// UpdateBankQuestion changes a bank question and the position questions linked to it.
+func (r *questionBankRepository) UpdateBankQuestion(ctx context.Context, q *models.BankQuestion) (*models.BankQuestion, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int
+	query := `UPDATE bank_questions AS b
+		SET name = COALESCE($2, name),
+			question_type = COALESCE($3, question_type),
+			tags = COALESCE($4, tags),
+			read_duration = COALESCE($5, read_duration),
+			answer_duration = COALESCE($6, answer_duration),
+			updated_at = now()
+		WHERE public_id = $1
+		RETURNING ` + bankQuestionColumns + `, b.id`
+	res, err := scanBankQuestion(tx.QueryRow(ctx, query, q.PublicID, q.Name, q.Type, q.Tags, q.ReadDuration, q.AnswerDuration), &id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrBankQuestionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while updating bank question: %v", err)
+		return nil, err
+	}
+
+	query = `UPDATE questions
+		SET name = $2, question_type = $3, read_duration = $4, answer_duration = $5
+		WHERE bank_question_id = $1 AND linked`
+	if _, err := tx.Exec(ctx, query, id, res.Name, res.Type, res.ReadDuration, res.AnswerDuration); err != nil {
+		r.log(ctx).Errorf("Error occurred while updating linked questions: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteBankQuestion removes a bank question. Position questions taken from it
+// keep their content as independent questions.
+func (r *questionBankRepository) DeleteBankQuestion(ctx context.Context, publicID string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE questions SET linked = false
+		WHERE bank_question_id = (SELECT id FROM bank_questions WHERE public_id = $1)`
+	if _, err := tx.Exec(ctx, query, publicID); err != nil {
+		r.log(ctx).Errorf("Error occurred while detaching linked questions: %v", err)
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM bank_questions WHERE public_id = $1`, publicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while deleting bank question: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrBankQuestionNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *questionBankRepository) GetBankQuestionCompany(ctx context.Context, publicID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	var companyPublicID string
+	query := `SELECT company_public_id FROM bank_questions WHERE public_id = $1`
+	err := r.db.QueryRow(ctx, query, publicID).Scan(&companyPublicID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", models.ErrBankQuestionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while getting bank question company: %v", err)
+		return "", err
+	}
+	return companyPublicID, nil
+}
+
+// AttachBankQuestions adds bank questions of the position's company to the
+// position, linked or as copies.
+func (r *questionBankRepository) AttachBankQuestions(ctx context.Context, positionPublicID string, attachments []models.BankAttachment) ([]*models.Question, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		positionID      int
+		companyPublicID string
+	)
+	query := `SELECT p.id, r.company_public_id
+		FROM positions p
+		INNER JOIN recruiters r ON r.public_id = p.recruiter_public_id
+		WHERE p.public_id = $1 AND p.deleted_at IS NULL
+		FOR UPDATE OF p`
+	err = tx.QueryRow(ctx, query, positionPublicID).Scan(&positionID, &companyPublicID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrPositionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while retrieving position: %v", err)
+		return nil, err
+	}
+
+	query = `INSERT INTO questions (name, position_public_id, position_id, read_duration, answer_duration, question_type, bank_question_id, linked, ordinal)
+		SELECT b.name, $1, $2, b.read_duration, b.answer_duration, b.question_type, b.id, $5,
+			(SELECT COALESCE(MAX(ordinal), 0) + 1 FROM questions WHERE position_id = $2)
+		FROM bank_questions b
+		WHERE b.public_id = $3 AND b.company_public_id = $4
+		RETURNING public_id, name, read_duration, answer_duration, question_type, linked, ordinal`
+	questions := make([]*models.Question, 0, len(attachments))
+	for _, a := range attachments {
+		bankPublicID := a.PublicID
+		q := &models.Question{BankQuestionPublicID: &bankPublicID}
+		err := tx.QueryRow(ctx, query, positionPublicID, positionID, a.PublicID, companyPublicID, !a.Copy).Scan(
+			&q.PublicID,
+			&q.Name,
+			&q.ReadDuration,
+			&q.AnswerDuration,
+			&q.Type,
+			&q.Linked,
+			&q.Ordinal,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, models.ErrBankQuestionNotFound
+			}
+			r.log(ctx).Errorf("Error occurred while attaching bank question: %v", err)
+			return nil, err
+		}
+		questions = append(questions, q)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return nil, err
+	}
+	return questions, nil
+}
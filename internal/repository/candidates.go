@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// GetCandidateInterviews returns the interviews of a candidate, newest first,
+// with the total number of them.
+func (r *interviewRepository) GetCandidateInterviews(ctx context.Context, candidatePublicID string, page *models.Pagination) ([]*models.CandidateInterview, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	var count int
+	query := `SELECT COUNT(*)
+		FROM user_interviews ui
+		INNER JOIN candidates c ON c.id = ui.candidate_id
+		WHERE c.public_id = $1`
+	if err := r.db.QueryRow(ctx, query, candidatePublicID).Scan(&count); err != nil {
+		r.log(ctx).Errorf("Error occurred while counting candidate interviews: %v", err)
+		return nil, 0, err
+	}
+
+	query = `SELECT i.public_id, i.status, i.results, i.scoring, i.created_at, i.started_at, i.finished_at, i.expires_at,
+			p.public_id, p.name, p.status, p.result_visibility, co.public_id, co.name, co.logo
+		FROM interviews i
+		INNER JOIN user_interviews ui ON ui.interview_id = i.id
+		INNER JOIN candidates c ON c.id = ui.candidate_id
+		INNER JOIN positions p ON p.id = ui.position_id
+		LEFT JOIN recruiters r ON r.public_id = p.recruiter_public_id
+		LEFT JOIN companies co ON co.public_id = r.company_public_id
+		WHERE c.public_id = $1
+		ORDER BY i.id DESC
+		LIMIT ` + strconv.Itoa(page.PageSize) + ` OFFSET ` + strconv.Itoa((page.PageNum-1)*page.PageSize)
+
+	rows, err := r.db.Query(ctx, query, candidatePublicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while retrieving candidate interviews: %v", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	interviews := []*models.CandidateInterview{}
+	for rows.Next() {
+		interview := &models.CandidateInterview{
+			Position: &models.Position{Company: &models.Company{}},
+		}
+		err := rows.Scan(
+			&interview.PublicID,
+			&interview.Status,
+			&interview.RawResult,
+			&interview.RawScoring,
+			&interview.CreatedAt,
+			&interview.StartedAt,
+			&interview.FinishedAt,
+			&interview.ExpiresAt,
+			&interview.Position.PublicID,
+			&interview.Position.Name,
+			&interview.Position.Status,
+			&interview.Position.ResultVisibility,
+			&interview.Position.Company.PublicID,
+			&interview.Position.Company.Name,
+			&interview.Position.Company.Logo,
+		)
+		if err != nil {
+			r.log(ctx).Errorf("Error scanning candidate interview row: %v", err)
+			return nil, 0, err
+		}
+		interviews = append(interviews, interview)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over candidate interview rows: %v", err)
+		return nil, 0, err
+	}
+	return interviews, count, nil
+}
+
+// GetCandidateApplications returns, for every position a candidate has
+// interviewed for, the number of attempts and the latest interview, most
+// recently active first. defaultMaxAttempts applies to positions without a limit of their own.
+func (r *interviewRepository) GetCandidateApplications(ctx context.Context, candidatePublicID string, defaultMaxAttempts int, page *models.Pagination) ([]*models.Application, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	var count int
+	query := `SELECT COUNT(DISTINCT ui.position_id)
+		FROM user_interviews ui
+		INNER JOIN candidates c ON c.id = ui.candidate_id
+		WHERE c.public_id = $1`
+	if err := r.db.QueryRow(ctx, query, candidatePublicID).Scan(&count); err != nil {
+		r.log(ctx).Errorf("Error occurred while counting candidate applications: %v", err)
+		return nil, 0, err
+	}
+
+	query = `WITH latest AS (
+			SELECT DISTINCT ON (p.id) p.public_id AS position_public_id, p.name, p.status AS position_status,
+				COALESCE(p.max_attempts, $2) AS max_attempts, co.public_id AS company_public_id,
+				co.name AS company_name, co.logo, i.public_id, i.status,
+				COALESCE(i.finished_at, i.started_at, i.created_at) AS last_activity_at,
+				COUNT(*) OVER (PARTITION BY p.id) AS attempts
+			FROM interviews i
+			INNER JOIN user_interviews ui ON ui.interview_id = i.id
+			INNER JOIN candidates c ON c.id = ui.candidate_id
+			INNER JOIN positions p ON p.id = ui.position_id
+			LEFT JOIN recruiters r ON r.public_id = p.recruiter_public_id
+			LEFT JOIN companies co ON co.public_id = r.company_public_id
+			WHERE c.public_id = $1
+			ORDER BY p.id, i.id DESC
+		)
+		SELECT position_public_id, name, position_status, company_public_id, company_name, logo,
+			attempts, max_attempts, public_id, status, last_activity_at
+		FROM latest
+		ORDER BY last_activity_at DESC, position_public_id
+		LIMIT ` + strconv.Itoa(page.PageSize) + ` OFFSET ` + strconv.Itoa((page.PageNum-1)*page.PageSize)
+
+	rows, err := r.db.Query(ctx, query, candidatePublicID, defaultMaxAttempts)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while retrieving candidate applications: %v", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	applications := []*models.Application{}
+	for rows.Next() {
+		application := &models.Application{
+			Position: &models.Position{Company: &models.Company{}},
+		}
+		err := rows.Scan(
+			&application.Position.PublicID,
+			&application.Position.Name,
+			&application.Position.Status,
+			&application.Position.Company.PublicID,
+			&application.Position.Company.Name,
+			&application.Position.Company.Logo,
+			&application.Attempts,
+			&application.MaxAttempts,
+			&application.InterviewPublicID,
+			&application.Status,
+			&application.LastActivityAt,
+		)
+		if err != nil {
+			r.log(ctx).Errorf("Error scanning candidate application row: %v", err)
+			return nil, 0, err
+		}
+		applications = append(applications, application)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over candidate application rows: %v", err)
+		return nil, 0, err
+	}
+	return applications, count, nil
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Zhiyenbek/sp-positions-main-service/config"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"go.uber.org/zap"
 )
@@ -23,8 +26,12 @@ func NewCompanyRepository(db *pgxpool.Pool, cfg *config.DBConf, logger *zap.Suga
 	}
 }
 
-func (r *companyRepository) GetCompanyByRecruiterPublicID(recruiterPublicID string) (*models.Company, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *companyRepository) log(ctx context.Context) *zap.SugaredLogger {
+	return requestid.Logger(ctx, r.logger)
+}
+
+func (r *companyRepository) GetCompanyByRecruiterPublicID(ctx context.Context, recruiterPublicID string) (*models.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
 	query := `SELECT c.name, c.public_id, c.logo, c.description 
@@ -38,7 +45,10 @@ func (r *companyRepository) GetCompanyByRecruiterPublicID(recruiterPublicID stri
 	company := &models.Company{}
 	err := row.Scan(&company.Name, &company.PublicID, &company.Logo, &company.Description)
 	if err != nil {
-		r.logger.Errorf("Error occurred while fetching company: %v", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrCompanyDoesntExists
+		}
+		r.log(ctx).Errorf("Error occurred while fetching company: %v", err)
 		return nil, err
 	}
 
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// cursor marks the boundary row of a keyset page. Clients only see it as an
+// opaque string and must send it back unchanged along with the same sort.
+type cursor struct {
+	Sort      int    `json:"s"`
+	Ascending bool   `json:"a,omitempty"`
+	Value     string `json:"v"`
+	ID        int    `json:"id"`
+	Backward  bool   `json:"b,omitempty"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, models.ErrInvalidCursor
+	}
+	c := &cursor{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, models.ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// keysetKey is the sort value and id of a fetched row.
+type keysetKey struct {
+	value string
+	id    int
+}
+
+// pageInfo derives the neighbouring cursors of a fetched page. keys are in display
+// order and hasMore reports whether a row beyond the page was fetched.
+func pageInfo(template cursor, keys []keysetKey, hasMore bool, current *cursor, offset int) *models.PageInfo {
+	info := &models.PageInfo{}
+	if len(keys) == 0 {
+		return info
+	}
+
+	backward := current != nil && current.Backward
+	// walking backward we came from the rows after this page
+	moreAfter := backward || hasMore
+	moreBefore := hasMore
+	if !backward {
+		moreBefore = current != nil || offset > 0
+	}
+
+	if moreAfter {
+		next := template
+		next.Value, next.ID = keys[len(keys)-1].value, keys[len(keys)-1].id
+		info.NextCursor = encodeCursor(next)
+	}
+	if moreBefore {
+		prev := template
+		prev.Value, prev.ID = keys[0].value, keys[0].id
+		prev.Backward = true
+		info.PrevCursor = encodeCursor(prev)
+	}
+	return info
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
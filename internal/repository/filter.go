@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/jackc/pgx/v4"
+)
+
+// positionColumns is the column list scanned by scanPosition.
+const positionColumns = `
+	p.public_id,
+	p.name,
+	p.status,
+	p.status_changed_at,
+	p.created_at,
+	p.description,
+	p.recruiter_public_id,
+	c.public_id,
+	c.name,
+	c.description,
+	c.logo,
+	st.interview_count,
+	st.average_score,
+	` + searchColumns
+
+// positionStats counts the interviews of each listed position and averages their normalized scores.
+const positionStats = `LEFT JOIN LATERAL (
+		SELECT COUNT(*)::int AS interview_count, AVG((i.scoring->>'score')::float8) AS average_score
+		FROM user_interviews ui
+		INNER JOIN interviews i ON i.id = ui.interview_id
+		WHERE ui.position_id = p.id
+	) st ON true`
+
+// positionQuery builds the listing SQL shared by every position listing.
+type positionQuery struct {
+	filter     *models.PositionFilter
+	search     string
+	conditions []string
+	args       []interface{}
+}
+
+func newPositionQuery(filter *models.PositionFilter) *positionQuery {
+	q := &positionQuery{filter: filter}
+	q.search = q.arg(normalizeSearch(filter.Search))
+
+	q.where(searchFilter)
+	q.where(`p.deleted_at IS NULL`)
+	if filter.CompanyPublicID != "" {
+		q.where(`r.company_public_id = ` + q.arg(filter.CompanyPublicID))
+	}
+	if filter.RecruiterPublicID != "" {
+		q.where(`p.recruiter_public_id = ` + q.arg(filter.RecruiterPublicID))
+	}
+	if filter.Status != nil {
+		q.where(`p.status = ` + q.arg(*filter.Status))
+	}
+	if filter.CreatedFrom != nil {
+		q.where(`p.created_at >= ` + q.arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		q.where(`p.created_at <= ` + q.arg(*filter.CreatedTo))
+	}
+
+	if skills := normalizeSkills(filter.Skills); len(skills) > 0 {
+		matched := `SELECT COUNT(DISTINCT lower(s.name))
+			FROM position_skills ps
+			INNER JOIN skills s ON s.id = ps.skill_id
+			WHERE ps.position_id = p.id AND lower(s.name) = ANY(` + q.arg(skills) + `)`
+		if filter.SkillsMatch == models.TechnologyMatchAll {
+			q.where(`(` + matched + `) = ` + q.arg(len(skills)))
+		} else {
+			q.where(`(` + matched + `) > 0`)
+		}
+	}
+
+	return q
+}
+
+// arg registers a query argument and returns its placeholder.
+func (q *positionQuery) arg(value interface{}) string {
+	q.args = append(q.args, value)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+func (q *positionQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+func (q *positionQuery) from() string {
+	return `positions p
+		` + searchJoin(q.search) + `
+		INNER JOIN recruiters r ON p.recruiter_public_id = r.public_id`
+}
+
+func (q *positionQuery) countSQL() string {
+	return `SELECT COUNT(*) FROM ` + q.from() + `
+		WHERE ` + strings.Join(q.conditions, " AND ")
+}
+
+// selectSQL returns the page query. Besides positionColumns every row carries
+// p.id and the text form of its sort key for building cursors. backward reverses
+// the order to walk towards the start of the listing.
+func (q *positionQuery) selectSQL(limit, offset int, backward bool) string {
+	expr, _ := q.sortKey()
+	return `SELECT ` + positionColumns + `, p.id, (` + expr + `)::text
+		FROM ` + q.from() + `
+		INNER JOIN companies c ON r.company_public_id = c.public_id
+		` + positionStats + `
+		WHERE ` + strings.Join(q.conditions, " AND ") + `
+		ORDER BY ` + q.orderBy(backward) + `
+		LIMIT ` + strconv.Itoa(limit) + ` OFFSET ` + strconv.Itoa(offset)
+}
+
+// sortKey returns the expression positions are ordered by and its SQL type.
+// Fractional keys are rounded so their text form compares exactly in cursors.
+func (q *positionQuery) sortKey() (string, string) {
+	switch q.filter.Sort {
+	case models.SortByName:
+		return `lower(coalesce(p.name, ''))`, "text"
+	case models.SortByInterviewCount:
+		return `st.interview_count`, "int"
+	case models.SortByAverageScore:
+		return `round(coalesce(st.average_score, -1)::numeric, 6)`, "numeric"
+	case models.SortByRelevance:
+		if normalizeSearch(q.filter.Search) != "" {
+			// a search without lexemes ranks every position alike
+			return `round(coalesce(ts_rank_cd(p.search_vector, q.query), 0)::numeric, 6)`, "numeric"
+		}
+	}
+	return `p.created_at`, "timestamp"
+}
+
+func (q *positionQuery) ascending(backward bool) bool {
+	return q.filter.Ascending != backward
+}
+
+func (q *positionQuery) orderBy(backward bool) string {
+	direction := "DESC"
+	if q.ascending(backward) {
+		direction = "ASC"
+	}
+	expr, _ := q.sortKey()
+	// the id keeps the order stable between pages when sort values tie
+	return fmt.Sprintf("%s %s, p.id %s", expr, direction, direction)
+}
+
+// after restricts the listing to rows past the cursor in its walking direction.
+// It must be called after countSQL, since it changes the matched rows.
+func (q *positionQuery) after(c *cursor) {
+	expr, typ := q.sortKey()
+	op := "<"
+	if q.ascending(c.Backward) {
+		op = ">"
+	}
+	q.where(fmt.Sprintf("(%s, p.id) %s (%s::text::%s, %s::int)", expr, op, q.arg(c.Value), typ, q.arg(c.ID)))
+}
+
+// cursor returns the template of cursors issued for this listing.
+func (q *positionQuery) cursor() cursor {
+	return cursor{Sort: q.filter.Sort, Ascending: q.filter.Ascending}
+}
+
+func normalizeSkills(skills []string) []string {
+	seen := make(map[string]bool)
+	res := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		res = append(res, skill)
+	}
+	return res
+}
+
+func scanPosition(rows pgx.Rows, extra ...interface{}) (*models.Position, error) {
+	position := &models.Position{Company: &models.Company{}}
+	match := searchResult{}
+	err := rows.Scan(append([]interface{}{
+		&position.PublicID,
+		&position.Name,
+		&position.Status,
+		&position.StatusChangedAt,
+		&position.CreatedAt,
+		&position.Description,
+		&position.RecruiterPublicID,
+		&position.Company.PublicID,
+		&position.Company.Name,
+		&position.Company.Description,
+		&position.Company.Logo,
+		&position.InterviewCount,
+		&position.AverageScore,
+	}, append(match.dest(), extra...)...)...)
+	if err != nil {
+		return nil, err
+	}
+	match.apply(position)
+	return position, nil
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+)
+
+type interviewRepository struct {
+	db     *pgxpool.Pool
+	cfg    *config.DBConf
+	logger *zap.SugaredLogger
+}
+
+func NewInterviewRepository(db *pgxpool.Pool, cfg *config.DBConf, logger *zap.SugaredLogger) InterviewRepository {
+	return &interviewRepository{
+		db:     db,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *interviewRepository) log(ctx context.Context) *zap.SugaredLogger {
+	return requestid.Logger(ctx, r.logger)
+}
+
+func (r *interviewRepository) GetInterview(ctx context.Context, publicID string) (*models.Interview, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `SELECT i.public_id, c.public_id, p.public_id, i.status, COALESCE(i.decision, ''),
+			i.created_at, i.started_at, i.finished_at, i.expires_at
+		FROM interviews i
+		INNER JOIN user_interviews ui ON ui.interview_id = i.id
+		INNER JOIN candidates c ON c.id = ui.candidate_id
+		INNER JOIN positions p ON p.id = ui.position_id
+		WHERE i.public_id = $1`
+
+	interview := &models.Interview{}
+	err := r.db.QueryRow(ctx, query, publicID).Scan(
+		&interview.PublicID,
+		&interview.CandidatePublicID,
+		&interview.PositionPublicID,
+		&interview.Status,
+		&interview.Decision,
+		&interview.CreatedAt,
+		&interview.StartedAt,
+		&interview.FinishedAt,
+		&interview.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrInterviewNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while retrieving interview: %v", err)
+		return nil, err
+	}
+	return interview, nil
+}
+
+// GetInterviewQuestions returns the questions snapshotted when the interview was created, in order.
+func (r *interviewRepository) GetInterviewQuestions(ctx context.Context, publicID string) ([]*models.Question, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `SELECT iq.question_public_id, iq.name, iq.read_duration, iq.answer_duration, COALESCE(iq.question_type, ''),
+			iq.ordinal, COALESCE(iq.difficulty, ''), COALESCE(iq.rubric, ''), iq.max_retakes, iq.skills
+		FROM interview_questions iq
+		INNER JOIN interviews i ON i.id = iq.interview_id
+		WHERE i.public_id = $1
+		ORDER BY iq.ordinal`
+
+	rows, err := r.db.Query(ctx, query, publicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while querying questions for interview: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []*models.Question{}
+	for rows.Next() {
+		question := &models.Question{}
+		err := rows.Scan(
+			&question.PublicID,
+			&question.Name,
+			&question.ReadDuration,
+			&question.AnswerDuration,
+			&question.Type,
+			&question.Ordinal,
+			&question.Difficulty,
+			&question.Rubric,
+			&question.MaxRetakes,
+			&question.Skills,
+		)
+		if err != nil {
+			r.log(ctx).Errorf("Error scanning interview question row: %v", err)
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over interview question rows: %v", err)
+		return nil, err
+	}
+	return questions, nil
+}
+
+// StartInterview moves a scheduled interview that has not expired yet in progress,
+// giving the candidate duration to finish it.
+func (r *interviewRepository) StartInterview(ctx context.Context, publicID string, duration time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `UPDATE interviews
+		SET status = $2, started_at = now(), expires_at = now() + $3::interval
+		WHERE public_id = $1 AND status = $4 AND (expires_at IS NULL OR expires_at > now())`
+
+	tag, err := r.db.Exec(ctx, query, publicID, models.InterviewStatusInProgress, duration, models.InterviewStatusScheduled)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting interview: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrInvalidTransition
+	}
+	return nil
+}
+
+// FinishInterview moves an interview from status from to the final status to.
+// It fails with models.ErrInvalidTransition when the interview is no longer in from.
+func (r *interviewRepository) FinishInterview(ctx context.Context, publicID, from, to string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `UPDATE interviews
+		SET status = $3, finished_at = now()
+		WHERE public_id = $1 AND status = $2`
+
+	tag, err := r.db.Exec(ctx, query, publicID, from, to)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while finishing interview: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrInvalidTransition
+	}
+	return nil
+}
+
+// ExpireInterviews marks every scheduled or in progress interview past its deadline expired.
+func (r *interviewRepository) ExpireInterviews(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `UPDATE interviews
+		SET status = $1, finished_at = now()
+		WHERE status IN ($2, $3) AND expires_at <= now()`
+
+	tag, err := r.db.Exec(ctx, query, models.InterviewStatusExpired, models.InterviewStatusScheduled, models.InterviewStatusInProgress)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while expiring interviews: %v", err)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// SaveResults stores result as the next version of the interview's results,
+// marks it evaluated and scores it. Only submitted or already evaluated interviews take results.
+func (r *interviewRepository) SaveResults(ctx context.Context, publicID string, result *models.Result, defaults *config.EvaluationConf) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int
+	var status string
+	err = tx.QueryRow(ctx, `SELECT id, status FROM interviews WHERE public_id = $1 FOR UPDATE`, publicID).Scan(&id, &status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, models.ErrInterviewNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while locking interview: %v", err)
+		return 0, err
+	}
+	if status != models.InterviewStatusSubmitted && status != models.InterviewStatusEvaluated {
+		return 0, models.ErrInvalidTransition
+	}
+
+	var version int
+	query := `INSERT INTO interview_results (interview_id, version, results)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2::jsonb FROM interview_results WHERE interview_id = $1
+		RETURNING version`
+	if err := tx.QueryRow(ctx, query, id, result).Scan(&version); err != nil {
+		r.log(ctx).Errorf("Error occurred while storing interview results: %v", err)
+		return 0, err
+	}
+
+	query = `UPDATE interviews SET results = $2, status = $3, finished_at = COALESCE(finished_at, now()) WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, id, result, models.InterviewStatusEvaluated); err != nil {
+		r.log(ctx).Errorf("Error occurred while updating interview results: %v", err)
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, rescoreQuery(`i.id = $6`), rescoreArgs(defaults, id)...); err != nil {
+		r.log(ctx).Errorf("Error occurred while scoring interview results: %v", err)
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return 0, err
+	}
+	return version, nil
+}
+
+// GetResults returns the stored results of the interview with the given public
+// id, or of every interview of the position when interviewPublicID is empty.
+// Interviews without results are skipped.
+func (r *interviewRepository) GetResults(ctx context.Context, interviewPublicID, positionPublicID string) ([]models.InterviewResults, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `SELECT i.public_id, i.results, c.public_id, i.status
+		FROM interviews i
+		INNER JOIN user_interviews ui ON ui.interview_id = i.id
+		INNER JOIN positions p ON p.id = ui.position_id
+		INNER JOIN candidates c ON c.id = ui.candidate_id
+		WHERE i.results IS NOT NULL
+			AND ($1 = '' OR i.public_id::text = $1)
+			AND ($2 = '' OR p.public_id::text = $2)
+		ORDER BY i.id`
+
+	rows, err := r.db.Query(ctx, query, interviewPublicID, positionPublicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while retrieving interview results: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []models.InterviewResults{}
+	for rows.Next() {
+		result := models.InterviewResults{}
+		if err := rows.Scan(&result.PublicID, &result.Result, &result.CandidatePublicID, &result.Status); err != nil {
+			r.log(ctx).Errorf("Error scanning interview result row: %v", err)
+			return nil, err
+		}
+		res = append(res, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over interview result rows: %v", err)
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// GetLeaderboard ranks the candidates of a position by the normalized score of
+// their best attempt. Ties are broken by the normalized tech score, then by who finished first.
+// Percentile is the share of ranked candidates scoring below the entry.
+func (r *interviewRepository) GetLeaderboard(ctx context.Context, positionPublicID string, filter *models.LeaderboardFilter, page *models.Pagination) ([]*models.LeaderboardEntry, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	ranking := `WITH attempts AS (
+			SELECT i.id, i.public_id, i.status, i.finished_at, c.public_id AS candidate_public_id,
+				(i.scoring->>'score')::float8 AS score, (i.scoring->>'passed')::boolean AS passed,
+				(i.scoring->>'tech_score')::float8 AS tech_score, (i.scoring->>'soft_score')::float8 AS soft_score,
+				COUNT(*) OVER (PARTITION BY c.id) AS attempts
+			FROM interviews i
+			INNER JOIN user_interviews ui ON ui.interview_id = i.id
+			INNER JOIN positions p ON p.id = ui.position_id
+			INNER JOIN candidates c ON c.id = ui.candidate_id
+			WHERE p.public_id = $1 AND i.status = ANY($2)
+		), best AS (
+			SELECT DISTINCT ON (candidate_public_id) *
+			FROM attempts
+			ORDER BY candidate_public_id, score DESC NULLS LAST, tech_score DESC NULLS LAST, finished_at ASC NULLS LAST, id
+		), ranked AS (
+			SELECT *,
+				RANK() OVER (ORDER BY score DESC NULLS LAST, tech_score DESC NULLS LAST, finished_at ASC NULLS LAST) AS rank,
+				percent_rank() OVER (ORDER BY score ASC NULLS FIRST) AS percentile
+			FROM best
+		)`
+	const minScore = `$3::float8 IS NULL OR score >= $3`
+	args := []interface{}{positionPublicID, filter.Statuses, filter.MinScore}
+
+	// counted apart from the page, so a page past the end still reports the total
+	var count int
+	if err := r.db.QueryRow(ctx, ranking+` SELECT COUNT(*) FROM ranked WHERE `+minScore, args...).Scan(&count); err != nil {
+		r.log(ctx).Errorf("Error occurred while counting leaderboard entries: %v", err)
+		return nil, 0, err
+	}
+
+	query := ranking + `
+		SELECT rank, candidate_public_id, public_id, status, score, passed, tech_score, soft_score,
+			round((percentile * 100)::numeric, 2)::float8, attempts, finished_at
+		FROM ranked
+		WHERE ` + minScore + `
+		ORDER BY rank, id
+		LIMIT ` + strconv.Itoa(page.PageSize) + ` OFFSET ` + strconv.Itoa((page.PageNum-1)*page.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while retrieving leaderboard: %v", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []*models.LeaderboardEntry{}
+	for rows.Next() {
+		entry := &models.LeaderboardEntry{}
+		err := rows.Scan(
+			&entry.Rank,
+			&entry.CandidatePublicID,
+			&entry.InterviewPublicID,
+			&entry.Status,
+			&entry.Score,
+			&entry.Passed,
+			&entry.TechScore,
+			&entry.SoftScore,
+			&entry.Percentile,
+			&entry.Attempts,
+			&entry.FinishedAt,
+		)
+		if err != nil {
+			r.log(ctx).Errorf("Error scanning leaderboard row: %v", err)
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over leaderboard rows: %v", err)
+		return nil, 0, err
+	}
+	return entries, count, nil
+}
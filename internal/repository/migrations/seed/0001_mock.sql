@@ -0,0 +1,137 @@
+-- Mock data for local development, applied by the opt-in seed step
+
+INSERT INTO users (first_name, last_name, photo, email)
+VALUES
+    ('John', 'Doe', 'path/to/photo1', 'example@mail.com'),
+    ('Jane', 'Smith', 'path/to/photo2', 'example@mail.com'),
+    ('Michael', 'Johnson', 'path/to/photo3', 'example@mail.com'),
+    ('Emily', 'Williams', 'path/to/photo4', 'example@mail.com'),
+    ('David', 'Brown', 'path/to/photo5', 'example@mail.com'),
+    ('Olivia', 'Jones', 'path/to/photo6', 'example@mail.com'),
+    ('Daniel', 'Miller','path/to/photo7', 'example@mail.com'),
+    ('Sophia', 'Taylor','path/to/photo8', 'example@mail.com'),
+    ('Matthew', 'Anderson','path/to/photo9', 'example@mail.com'),
+    ('Ava', 'Thomas','path/to/photo10', 'example@mail.com');
+
+
+INSERT INTO candidates (public_id, current_position, resume, bio, education)
+SELECT public_id, 'Software Engineer', 'John Doe Resume', 'John Doe Bio',  'MTI'
+FROM users
+WHERE id <= 5;
+
+INSERT INTO companies (name, description, logo)
+VALUES
+    ('Company A', 'A technology company that specializes in software development.','path/to/logo1'),
+    ('Company B', 'A global retail company with a focus on e-commerce.','path/to/logo2'),
+    ('Company C', 'A financial services company providing investment and banking solutions.','path/to/logo3');
+
+INSERT INTO recruiters (public_id, company_public_id)
+SELECT public_id, (SELECT public_id FROM companies WHERE name = 'Company A')
+FROM users
+WHERE id > 5;
+
+
+
+
+-- status 1 is open, so the seeded positions accept interviews; new positions start as drafts (0)
+INSERT INTO positions (public_id, name, status, recruiter_public_id, description)
+SELECT public_id, 'Software Engineer', 1, (SELECT public_id FROM recruiters WHERE id = 1), 'This position is awesome'
+FROM candidates;
+
+
+INSERT INTO skills (name)
+VALUES
+    ('Java'),
+    ('Python'),
+    ('JavaScript'),
+    ('SQL'),
+    ('HTML'),
+    ('CSS'),
+    ('React'),
+    ('Node.js'),
+    ('AWS'),
+    ('Agile Methodology');
+
+INSERT INTO areas (position_id, name)
+SELECT id, 'Area ' || id
+FROM positions;
+
+
+INSERT INTO interviews (public_id, status, started_at, finished_at, results)
+SELECT public_id, 'evaluated', now() - interval '1 hour', now(), '
+{
+  "questions": [
+    {
+      "question": "What is your experience with object-oriented programming?",
+      "evaluation": "Good",
+      "question_type": "tech",
+      "score": 8,
+      "video_link": "https://example.com/video1",
+      "emotion_results": [
+        {
+          "emotion": "Happiness",
+          "exact_time": 24.5,
+          "duration": 10.2
+        },
+        {
+          "emotion": "Neutral",
+          "exact_time": 36.2,
+          "duration": 5.7
+        }
+      ]
+    },
+    {
+      "question": "Describe a challenging project you have worked on.",
+      "question_type": "tech",
+      "evaluation": "Excellent performance with exceptional problem-solving skills",
+      "score": 9,
+      "video_link": "https://example.com/video2",
+      "emotion_results": [
+        {
+          "emotion": "Confidence",
+          "exact_time": 45.8,
+          "duration": 8.5
+        },
+        {
+          "emotion": "Determination",
+          "exact_time": 56.3,
+          "duration": 7.1
+        }
+      ]
+    }
+  ],
+  "score": 17,
+  "video": "https://example.com/interview_video"
+}'
+FROM candidates;
+
+
+INSERT INTO videos (public_id, interviews_public_id, path)
+SELECT public_id, (SELECT public_id FROM interviews WHERE id = 1), '/path/to/video'
+FROM candidates;
+
+
+INSERT INTO auth (user_id, login, password)
+SELECT id, 'user' || id, '$2a$12$TPhE59oXJf8TBvbDRiBghu7jcgVppHgYPLmZr7ePf9rjNwVWJJDuO'
+FROM users;
+
+
+insert into position_skills values (1,2);
+insert into position_skills values (2,2);
+insert into position_skills values (1,3);
+insert into position_skills values (3,4);
+
+insert into candidate_skills values (1,2);
+insert into candidate_skills values (2,2);
+insert into candidate_skills values (1,3);
+insert into candidate_skills values (3,4);
+
+INSERT INTO user_interviews (candidate_id, position_id, interview_id)
+SELECT c.id AS candidate_id, p.id AS position_id, i.id AS interview_id
+FROM candidates c
+CROSS JOIN positions p
+CROSS JOIN interviews i
+WHERE c.id <= 5;
+
+INSERT INTO interview_results (interview_id, version, results)
+SELECT id, 1, results FROM interviews WHERE results IS NOT NULL;
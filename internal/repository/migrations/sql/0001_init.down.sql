@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS user_interviews;
+DROP TABLE IF EXISTS candidate_skills;
+DROP TABLE IF EXISTS position_skills;
+DROP TABLE IF EXISTS questions;
+DROP TABLE IF EXISTS auth;
+DROP TABLE IF EXISTS videos;
+DROP TABLE IF EXISTS interviews;
+DROP TABLE IF EXISTS areas;
+DROP TABLE IF EXISTS skills;
+DROP TABLE IF EXISTS positions;
+DROP TABLE IF EXISTS companies;
+DROP TABLE IF EXISTS recruiters;
+DROP TABLE IF EXISTS candidates;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,117 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    first_name TEXT NOT NULL,
+    last_name TEXT,
+    email TEXT,
+    photo TEXT
+);
+
+CREATE TABLE IF NOT EXISTS candidates (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE NOT NULL,
+    current_position TEXT,
+    education TEXT,
+    resume TEXT,
+    bio TEXT,
+    CONSTRAINT fk_candidates_users FOREIGN KEY (public_id) REFERENCES users(public_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS recruiters (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE NOT NULL,
+    company_public_id UUID NOT NULL,
+    CONSTRAINT fk_recruiters_users FOREIGN KEY (public_id) REFERENCES users(public_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS companies (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    name TEXT,
+    logo TEXT,
+    description TEXT
+);
+
+CREATE TABLE IF NOT EXISTS positions (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    description TEXT,
+    name TEXT,
+    status int DEFAULT 0,
+    recruiter_public_id UUID NOT NULL,
+    CONSTRAINT fk_positions_recruiters FOREIGN KEY (recruiter_public_id) REFERENCES recruiters(public_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS skills (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS areas (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    position_id INT,
+    name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS interviews (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    results JSONB
+);
+
+CREATE TABLE IF NOT EXISTS videos (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    interviews_public_id UUID,
+    path TEXT,
+    CONSTRAINT fk_videos_interviews FOREIGN KEY (interviews_public_id) REFERENCES interviews(public_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS auth (
+    id SERIAL PRIMARY KEY,
+    user_id INT UNIQUE,
+    login TEXT UNIQUE,
+    password TEXT,
+    CONSTRAINT fk_auth_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS questions (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    name TEXT NOT NULL,
+    position_public_id UUID NOT NULL,
+    position_id INT NOT NULL,
+    read_duration INT NOT NULL DEFAULT 0,
+    answer_duration INT NOT NULL DEFAULT 0,
+    CONSTRAINT fk_questions_positions FOREIGN KEY (position_id) REFERENCES positions(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS position_skills (
+    position_id INT,
+    skill_id INT,
+    PRIMARY KEY (position_id, skill_id),
+    CONSTRAINT fk_position_skills_positions FOREIGN KEY (position_id) REFERENCES positions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_position_skills_skills FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS candidate_skills (
+    candidate_id INT,
+    skill_id INT,
+    PRIMARY KEY (candidate_id, skill_id),
+    CONSTRAINT fk_candidate_skills_candidates FOREIGN KEY (candidate_id) REFERENCES candidates(id) ON DELETE CASCADE,
+    CONSTRAINT fk_candidate_skills_skills FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_interviews (
+    candidate_id INT,
+    position_id INT,
+    interview_id INT,
+    PRIMARY KEY (candidate_id, position_id, interview_id),
+    CONSTRAINT fk_user_interviews_candidates FOREIGN KEY (candidate_id) REFERENCES candidates(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_interviews_positions FOREIGN KEY (position_id) REFERENCES positions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_interviews_interviews FOREIGN KEY (interview_id) REFERENCES interviews(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE positions DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE positions DROP COLUMN IF EXISTS status_changed_at;
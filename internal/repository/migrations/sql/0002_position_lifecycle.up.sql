@@ -0,0 +1,2 @@
+ALTER TABLE positions ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP DEFAULT now();
+ALTER TABLE positions ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_positions_search_vector;
+
+DROP TRIGGER IF EXISTS companies_search_vector_update ON companies;
+DROP TRIGGER IF EXISTS skills_search_vector_update ON skills;
+DROP TRIGGER IF EXISTS position_skills_search_vector_update ON position_skills;
+DROP TRIGGER IF EXISTS positions_search_vector_update ON positions;
+
+DROP FUNCTION IF EXISTS companies_search_vector_trigger();
+DROP FUNCTION IF EXISTS skills_search_vector_trigger();
+DROP FUNCTION IF EXISTS position_skills_search_vector_trigger();
+DROP FUNCTION IF EXISTS positions_search_vector_trigger();
+DROP FUNCTION IF EXISTS positions_refresh_search_vector(INT[]);
+DROP FUNCTION IF EXISTS positions_build_search_vector(INT, TEXT, TEXT, UUID);
+
+ALTER TABLE positions DROP COLUMN IF EXISTS search_vector;
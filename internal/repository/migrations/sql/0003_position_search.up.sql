@@ -0,0 +1,91 @@
+ALTER TABLE positions ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+-- name, skills, company and description are weighted A to D for ranking
+CREATE OR REPLACE FUNCTION positions_build_search_vector(p_id INT, p_name TEXT, p_description TEXT, p_recruiter UUID)
+RETURNS tsvector AS $$
+    SELECT
+        setweight(to_tsvector('simple', coalesce(p_name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce((
+            SELECT string_agg(s.name, ' ')
+            FROM position_skills ps
+            INNER JOIN skills s ON s.id = ps.skill_id
+            WHERE ps.position_id = p_id
+        ), '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce((
+            SELECT c.name
+            FROM recruiters r
+            INNER JOIN companies c ON c.public_id = r.company_public_id
+            WHERE r.public_id = p_recruiter
+        ), '')), 'C') ||
+        setweight(to_tsvector('simple', coalesce(p_description, '')), 'D')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION positions_refresh_search_vector(p_ids INT[]) RETURNS void AS $$
+    UPDATE positions p
+    SET search_vector = positions_build_search_vector(p.id, p.name, p.description, p.recruiter_public_id)
+    WHERE p.id = ANY(p_ids);
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION positions_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := positions_build_search_vector(NEW.id, NEW.name, NEW.description, NEW.recruiter_public_id);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION position_skills_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM positions_refresh_search_vector(ARRAY[OLD.position_id]);
+        RETURN OLD;
+    END IF;
+    PERFORM positions_refresh_search_vector(ARRAY[NEW.position_id]);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION skills_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    PERFORM positions_refresh_search_vector(ARRAY(
+        SELECT position_id FROM position_skills WHERE skill_id = NEW.id
+    ));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION companies_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    PERFORM positions_refresh_search_vector(ARRAY(
+        SELECT p.id
+        FROM positions p
+        INNER JOIN recruiters r ON r.public_id = p.recruiter_public_id
+        WHERE r.company_public_id = NEW.public_id
+    ));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS positions_search_vector_update ON positions;
+CREATE TRIGGER positions_search_vector_update
+    BEFORE INSERT OR UPDATE OF name, description, recruiter_public_id ON positions
+    FOR EACH ROW EXECUTE PROCEDURE positions_search_vector_trigger();
+
+DROP TRIGGER IF EXISTS position_skills_search_vector_update ON position_skills;
+CREATE TRIGGER position_skills_search_vector_update
+    AFTER INSERT OR DELETE ON position_skills
+    FOR EACH ROW EXECUTE PROCEDURE position_skills_search_vector_trigger();
+
+DROP TRIGGER IF EXISTS skills_search_vector_update ON skills;
+CREATE TRIGGER skills_search_vector_update
+    AFTER UPDATE OF name ON skills
+    FOR EACH ROW EXECUTE PROCEDURE skills_search_vector_trigger();
+
+DROP TRIGGER IF EXISTS companies_search_vector_update ON companies;
+CREATE TRIGGER companies_search_vector_update
+    AFTER UPDATE OF name ON companies
+    FOR EACH ROW EXECUTE PROCEDURE companies_search_vector_trigger();
+
+UPDATE positions
+SET search_vector = positions_build_search_vector(id, name, description, recruiter_public_id);
+
+CREATE INDEX IF NOT EXISTS idx_positions_search_vector ON positions USING GIN (search_vector);
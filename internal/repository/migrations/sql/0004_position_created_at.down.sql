@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_user_interviews_position_id;
+DROP INDEX IF EXISTS idx_positions_recruiter_public_id;
+DROP INDEX IF EXISTS idx_positions_created_at;
+
+ALTER TABLE positions DROP COLUMN IF EXISTS created_at;
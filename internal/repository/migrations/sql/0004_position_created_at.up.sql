@@ -0,0 +1,5 @@
+ALTER TABLE positions ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS idx_positions_created_at ON positions (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_positions_recruiter_public_id ON positions (recruiter_public_id);
+CREATE INDEX IF NOT EXISTS idx_user_interviews_position_id ON user_interviews (position_id);
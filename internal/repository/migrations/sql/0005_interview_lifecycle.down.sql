@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_interviews_active_expires_at;
+ALTER TABLE interviews DROP CONSTRAINT IF EXISTS interviews_status_check;
+ALTER TABLE interviews DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE interviews DROP COLUMN IF EXISTS finished_at;
+ALTER TABLE interviews DROP COLUMN IF EXISTS started_at;
+ALTER TABLE interviews DROP COLUMN IF EXISTS created_at;
+ALTER TABLE interviews DROP COLUMN IF EXISTS status;
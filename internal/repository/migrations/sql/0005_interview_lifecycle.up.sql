@@ -0,0 +1,14 @@
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'scheduled';
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT now();
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS started_at TIMESTAMP;
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS finished_at TIMESTAMP;
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+-- interviews recorded before statuses existed already carry their results
+UPDATE interviews SET status = 'evaluated', finished_at = now() WHERE results IS NOT NULL;
+
+ALTER TABLE interviews ADD CONSTRAINT interviews_status_check
+    CHECK (status IN ('scheduled', 'in_progress', 'submitted', 'evaluated', 'expired', 'cancelled'));
+
+CREATE INDEX IF NOT EXISTS idx_interviews_active_expires_at ON interviews (expires_at)
+    WHERE status IN ('scheduled', 'in_progress');
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_interviews_candidate_position;
+ALTER TABLE positions DROP COLUMN IF EXISTS max_attempts;
@@ -0,0 +1,4 @@
+-- NULL falls back to the configured default, 0 allows unlimited attempts
+ALTER TABLE positions ADD COLUMN IF NOT EXISTS max_attempts INT CHECK (max_attempts >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_user_interviews_candidate_position ON user_interviews (candidate_id, position_id);
@@ -0,0 +1,21 @@
+-- questions as they were when the interview was created, so later edits of the
+-- position's questions leave interviews already handed out unchanged
+CREATE TABLE IF NOT EXISTS interview_questions (
+    id SERIAL PRIMARY KEY,
+    interview_id INT NOT NULL,
+    question_public_id UUID NOT NULL,
+    ordinal INT NOT NULL,
+    name TEXT NOT NULL,
+    read_duration INT NOT NULL DEFAULT 0,
+    answer_duration INT NOT NULL DEFAULT 0,
+    CONSTRAINT uq_interview_questions UNIQUE (interview_id, question_public_id),
+    CONSTRAINT fk_interview_questions_interviews FOREIGN KEY (interview_id) REFERENCES interviews(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_questions_interview_ordinal ON interview_questions (interview_id, ordinal);
+
+INSERT INTO interview_questions (interview_id, question_public_id, ordinal, name, read_duration, answer_duration)
+SELECT ui.interview_id, q.public_id, row_number() OVER (PARTITION BY ui.interview_id ORDER BY q.id), q.name, q.read_duration, q.answer_duration
+FROM user_interviews ui
+INNER JOIN questions q ON q.position_id = ui.position_id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,14 @@
+-- every results submission is kept; interviews.results mirrors the latest version
+CREATE TABLE IF NOT EXISTS interview_results (
+    id SERIAL PRIMARY KEY,
+    interview_id INT NOT NULL,
+    version INT NOT NULL,
+    results JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT uq_interview_results_version UNIQUE (interview_id, version),
+    CONSTRAINT fk_interview_results_interviews FOREIGN KEY (interview_id) REFERENCES interviews(id) ON DELETE CASCADE
+);
+
+INSERT INTO interview_results (interview_id, version, results)
+SELECT id, 1, results FROM interviews WHERE results IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+-- the recorded answer of a candidate to one snapshotted interview question
+CREATE TABLE IF NOT EXISTS answers (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    interview_id INT NOT NULL,
+    question_public_id UUID NOT NULL,
+    video_public_id UUID,
+    transcript TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT uq_answers_interview_question UNIQUE (interview_id, question_public_id),
+    CONSTRAINT fk_answers_interviews FOREIGN KEY (interview_id) REFERENCES interviews(id) ON DELETE CASCADE,
+    CONSTRAINT fk_answers_videos FOREIGN KEY (video_public_id) REFERENCES videos(public_id) ON DELETE SET NULL
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS interview_notes;
+DROP TABLE IF EXISTS interview_decisions;
+DROP INDEX IF EXISTS idx_interviews_decision;
+ALTER TABLE interviews DROP CONSTRAINT IF EXISTS interviews_decision_check;
+ALTER TABLE interviews DROP COLUMN IF EXISTS decided_at;
+ALTER TABLE interviews DROP COLUMN IF EXISTS decision;
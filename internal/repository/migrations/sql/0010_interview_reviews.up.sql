@@ -0,0 +1,34 @@
+-- the current recruiter decision on an interview; interview_decisions keeps every change
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS decision TEXT;
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS decided_at TIMESTAMP;
+
+ALTER TABLE interviews ADD CONSTRAINT interviews_decision_check
+    CHECK (decision IN ('shortlisted', 'rejected', 'on_hold', 'hired'));
+
+CREATE INDEX IF NOT EXISTS idx_interviews_decision ON interviews (decision);
+
+CREATE TABLE IF NOT EXISTS interview_decisions (
+    id SERIAL PRIMARY KEY,
+    interview_id INT NOT NULL,
+    decision TEXT NOT NULL,
+    previous_decision TEXT,
+    author_public_id UUID NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT fk_interview_decisions_interviews FOREIGN KEY (interview_id) REFERENCES interviews(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_decisions_interview_id ON interview_decisions (interview_id);
+
+CREATE TABLE IF NOT EXISTS interview_notes (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    interview_id INT NOT NULL,
+    author_public_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT fk_interview_notes_interviews FOREIGN KEY (interview_id) REFERENCES interviews(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_notes_interview_id ON interview_notes (interview_id);
@@ -0,0 +1 @@
+ALTER TABLE positions DROP COLUMN IF EXISTS result_visibility;
@@ -0,0 +1,3 @@
+-- how much of their evaluated results candidates of a position may see
+ALTER TABLE positions ADD COLUMN IF NOT EXISTS result_visibility TEXT NOT NULL DEFAULT 'breakdown'
+    CHECK (result_visibility IN ('hidden', 'score', 'breakdown'));
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_questions_bank_question_id;
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS fk_questions_bank_questions;
+ALTER TABLE questions DROP COLUMN IF EXISTS linked;
+ALTER TABLE questions DROP COLUMN IF EXISTS bank_question_id;
+ALTER TABLE questions DROP COLUMN IF EXISTS question_type;
+DROP TABLE IF EXISTS bank_questions;
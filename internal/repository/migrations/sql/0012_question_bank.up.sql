@@ -0,0 +1,27 @@
+-- reusable questions of a company that recruiters attach to positions
+CREATE TABLE IF NOT EXISTS bank_questions (
+    id SERIAL PRIMARY KEY,
+    public_id UUID UNIQUE DEFAULT uuid_generate_v4() NOT NULL,
+    company_public_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    question_type TEXT NOT NULL CHECK (question_type IN ('tech', 'soft')),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    read_duration INT NOT NULL DEFAULT 0,
+    answer_duration INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT fk_bank_questions_companies FOREIGN KEY (company_public_id) REFERENCES companies(public_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_questions_company ON bank_questions (company_public_id);
+CREATE INDEX IF NOT EXISTS idx_bank_questions_tags ON bank_questions USING GIN (tags);
+
+-- a position question taken from the bank either follows later edits of its
+-- bank question (linked) or is an independent copy of it
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS question_type TEXT CHECK (question_type IN ('tech', 'soft'));
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS bank_question_id INT;
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS linked BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE questions ADD CONSTRAINT fk_questions_bank_questions
+    FOREIGN KEY (bank_question_id) REFERENCES bank_questions(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_questions_bank_question_id ON questions (bank_question_id) WHERE linked;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS question_skills;
+DROP INDEX IF EXISTS idx_questions_position_ordinal;
+ALTER TABLE questions DROP COLUMN IF EXISTS max_retakes;
+ALTER TABLE questions DROP COLUMN IF EXISTS rubric;
+ALTER TABLE questions DROP COLUMN IF EXISTS difficulty;
+ALTER TABLE questions DROP COLUMN IF EXISTS ordinal;
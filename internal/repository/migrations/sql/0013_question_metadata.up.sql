@@ -0,0 +1,21 @@
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS ordinal INT;
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS difficulty TEXT CHECK (difficulty IN ('easy', 'medium', 'hard'));
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS rubric TEXT;
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS max_retakes INT NOT NULL DEFAULT 0 CHECK (max_retakes >= 0);
+
+-- existing questions keep the order they were created in
+UPDATE questions q SET ordinal = o.ordinal
+FROM (SELECT id, row_number() OVER (PARTITION BY position_id ORDER BY id) AS ordinal FROM questions) o
+WHERE o.id = q.id;
+
+ALTER TABLE questions ALTER COLUMN ordinal SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_questions_position_ordinal ON questions (position_id, ordinal);
+
+CREATE TABLE IF NOT EXISTS question_skills (
+    question_id INT,
+    skill_id INT,
+    PRIMARY KEY (question_id, skill_id),
+    CONSTRAINT fk_question_skills_questions FOREIGN KEY (question_id) REFERENCES questions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_question_skills_skills FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE interviews DROP COLUMN IF EXISTS scoring;
+ALTER TABLE positions DROP COLUMN IF EXISTS pass_threshold;
+ALTER TABLE questions DROP COLUMN IF EXISTS max_score;
+ALTER TABLE questions DROP COLUMN IF EXISTS weight;
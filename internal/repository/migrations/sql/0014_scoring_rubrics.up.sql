@@ -0,0 +1,8 @@
+-- a question's share of the total score is its weight over the sum of the
+-- weights; scores are taken out of max_score, or the configured default
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS weight DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (weight > 0);
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS max_score INT CHECK (max_score > 0);
+ALTER TABLE positions ADD COLUMN IF NOT EXISTS pass_threshold DOUBLE PRECISION CHECK (pass_threshold BETWEEN 0 AND 100);
+
+-- the normalized score of the latest results, recomputed when the rubric changes
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS scoring JSONB;
@@ -0,0 +1,5 @@
+ALTER TABLE interview_questions DROP COLUMN IF EXISTS skills;
+ALTER TABLE interview_questions DROP COLUMN IF EXISTS max_retakes;
+ALTER TABLE interview_questions DROP COLUMN IF EXISTS rubric;
+ALTER TABLE interview_questions DROP COLUMN IF EXISTS difficulty;
+ALTER TABLE interview_questions DROP COLUMN IF EXISTS question_type;
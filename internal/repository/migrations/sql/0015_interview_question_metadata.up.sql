@@ -0,0 +1,18 @@
+-- the snapshot keeps the whole question, so editing its type, difficulty,
+-- rubric, retake limit or skills leaves interviews already handed out unchanged;
+-- skills are copied by name as skills may be merged later
+ALTER TABLE interview_questions ADD COLUMN IF NOT EXISTS question_type TEXT;
+ALTER TABLE interview_questions ADD COLUMN IF NOT EXISTS difficulty TEXT;
+ALTER TABLE interview_questions ADD COLUMN IF NOT EXISTS rubric TEXT;
+ALTER TABLE interview_questions ADD COLUMN IF NOT EXISTS max_retakes INT NOT NULL DEFAULT 0;
+ALTER TABLE interview_questions ADD COLUMN IF NOT EXISTS skills TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE interview_questions iq
+SET question_type = q.question_type,
+    difficulty = q.difficulty,
+    rubric = q.rubric,
+    max_retakes = q.max_retakes,
+    skills = ARRAY(SELECT s.name FROM question_skills qs INNER JOIN skills s ON s.id = qs.skill_id
+        WHERE qs.question_id = q.id ORDER BY s.name)
+FROM questions q
+WHERE q.public_id = iq.question_public_id;
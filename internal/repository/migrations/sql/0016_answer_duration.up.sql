@@ -0,0 +1,2 @@
+-- length of the recorded answer in seconds, as reported by the client
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS duration DOUBLE PRECISION CHECK (duration >= 0);
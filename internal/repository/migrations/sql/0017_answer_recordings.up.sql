@@ -0,0 +1,3 @@
+-- how many times the answer was recorded, the first recording included; the
+-- retakes a question allows are checked against it
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS recordings INT NOT NULL DEFAULT 1 CHECK (recordings > 0);
@@ -3,9 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/Zhiyenbek/sp-positions-main-service/config"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -26,158 +30,140 @@ func NewPositionRepository(db *pgxpool.Pool, cfg *config.DBConf, logger *zap.Sug
 	}
 }
 
-func (r *positionRepository) GetAllPositions(search string, pageNum, pageSize int) ([]models.Position, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
-	defer cancel()
+func (r *positionRepository) log(ctx context.Context) *zap.SugaredLogger {
+	return requestid.Logger(ctx, r.logger)
+}
 
-	offset := (pageNum - 1) * pageSize
-	// Query to retrieve positions for the current page
-	query := `
-		SELECT p.public_id, p.name, p.status, c.public_id, c.description, p.description, c.name, c.logo
-		FROM positions AS p
-		INNER JOIN recruiters r ON p.recruiter_public_id = r.public_id
-		INNER JOIN companies c ON r.company_public_id = c.public_id
-		WHERE p.name ILIKE $1
-		GROUP BY p.public_id, p.name, p.status, c.public_id, c.name, c.logo, c.description, p.description
-		LIMIT $2 OFFSET $3
-	`
+func (r *positionRepository) GetAllPositions(ctx context.Context, filter *models.PositionFilter, page *models.Pagination) ([]models.Position, *models.PageInfo, error) {
+	return r.listPositions(ctx, filter, page)
+}
 
-	rows, err := r.db.Query(ctx, query, "%"+search+"%", pageSize, offset)
-	if err != nil {
-		r.logger.Errorf("Error occurred while retrieving positions: %v", err)
-		return nil, 0, err
-	}
-	defer rows.Close()
+func (r *positionRepository) GetPositionInterviews(ctx context.Context, publicID string, filter *models.InterviewFilter, page *models.Pagination) ([]models.InterviewResults, *models.PageInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
 
-	positions := []models.Position{}
-	for rows.Next() {
-		position := models.Position{}
-		company := &models.Company{}
-		err := rows.Scan(
-			&position.PublicID,
-			&position.Name,
-			&position.Status,
-			&company.PublicID,
-			&company.Description,
-			&position.Description,
-			&company.Name,
-			&company.Logo,
-		)
+	// undecided interviews match the DecisionNone filter value
+	decisions := filter.Decisions
+	if decisions == nil {
+		decisions = []string{}
+	}
+	const decisionFilter = `(cardinality($2::text[]) = 0 OR COALESCE(i.decision, '` + models.DecisionNone + `') = ANY($2::text[]))`
 
-		position.Company = company
+	var count *int
+	if page.WithCount {
+		query := `
+			SELECT COUNT(*)
+			FROM interviews i
+			INNER JOIN user_interviews ui ON ui.interview_id = i.id
+			INNER JOIN positions p ON p.id = ui.position_id
+			WHERE p.public_id = $1 AND ` + decisionFilter + `
+		`
+		var totalCount int
+		err := r.db.QueryRow(ctx, query, publicID, decisions).Scan(&totalCount)
 		if err != nil {
-			r.logger.Errorf("Error occurred while scanning position rows: %v", err)
-			return nil, 0, err
-		}
-		query := `SELECT array_agg(s.name) from skills AS s
-		INNER JOIN position_skills ps ON ps.skill_id = s.id
-		INNER JOIN positions p ON ps.position_id = p.id
-		WHERE p.public_id = $1`
-		if position.PublicID != nil {
-			err = r.db.QueryRow(ctx, query, *position.PublicID).Scan(&position.Skills)
-			if err != nil {
-				r.logger.Errorf("Error retrieving positions by company: %v", err)
-				return nil, 0, err
-			}
+			r.log(ctx).Errorf("Error occurred while retrieving position count: %v", err)
+			return nil, nil, err
 		}
-		positions = append(positions, position)
+		count = &totalCount
 	}
 
-	if err := rows.Err(); err != nil {
-		r.logger.Errorf("Error occurred while iterating over position rows: %v", err)
-		return nil, 0, err
-	}
-
-	// Query to retrieve the total count of positions
-	countQuery := `
-		SELECT COUNT(*) FROM positions WHERE name ILIKE $1
-	`
-
-	var totalCount int
-	err = r.db.QueryRow(ctx, countQuery, "%"+search+"%").Scan(&totalCount)
-	if err != nil {
-		r.logger.Errorf("Error occurred while retrieving position count: %v", err)
-		return nil, 0, err
+	// interviews are keyed by (interview id, candidate id), as one interview may be linked to several candidates
+	var current *cursor
+	offset := 0
+	args := []interface{}{publicID, decisions}
+	keyset := "true"
+	direction, op := "ASC", ">"
+	if page.Cursor != "" {
+		c, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidateID, err := strconv.Atoi(c.Value)
+		if err != nil {
+			return nil, nil, models.ErrInvalidCursor
+		}
+		current = c
+		if c.Backward {
+			direction, op = "DESC", "<"
+		}
+		keyset = "(i.id, c.id) " + op + " ($3::int, $4::int)"
+		args = append(args, c.ID, candidateID)
+	} else {
+		offset = (page.PageNum - 1) * page.PageSize
 	}
 
-	return positions, totalCount, nil
-}
-
-func (r *positionRepository) GetPositionInterviews(publicID string, pageNum int, pageSize int) ([]models.InterviewResults, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
-	defer cancel()
-
 	query := `
-		SELECT i.public_id, i.results, c.public_id
+		SELECT i.public_id, i.results, i.scoring, c.public_id, i.status, COALESCE(i.decision, ''), i.id, c.id::text
 		FROM interviews i
 		INNER JOIN user_interviews ui ON ui.interview_id = i.id
 		INNER JOIN positions p ON p.id = ui.position_id
 		INNER JOIN candidates c ON c.id = ui.candidate_id
-		WHERE p.public_id = $1
-		GROUP BY i.public_id, i.results, c.public_id
-		LIMIT $2 OFFSET $3;
-	`
-	offset := (pageNum - 1) * pageSize
-	rows, err := r.db.Query(ctx, query, publicID, pageSize, offset)
+		WHERE p.public_id = $1 AND ` + decisionFilter + ` AND ` + keyset + `
+		ORDER BY i.id ` + direction + `, c.id ` + direction + `
+		LIMIT ` + strconv.Itoa(page.PageSize+1) + ` OFFSET ` + strconv.Itoa(offset)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		r.logger.Errorf("Error occurred while retrieving interview result: %v", err)
-		return nil, 0, err
+		r.log(ctx).Errorf("Error occurred while retrieving interview result: %v", err)
+		return nil, nil, err
 	}
 	defer rows.Close()
 	res := make([]models.InterviewResults, 0)
+	keys := make([]keysetKey, 0)
 	for rows.Next() {
 		var resultBytes []byte
+		var key keysetKey
 		result := models.InterviewResults{}
 		err = rows.Scan(
 			&result.PublicID,
 			&resultBytes,
+			&result.Scoring,
 			&result.CandidatePublicID,
+			&result.Status,
+			&result.Decision,
+			&key.id,
+			&key.value,
 		)
 		if err != nil {
-			r.logger.Errorf("Error occurred while retrieving interview result: %v", err)
-			return nil, 0, err
+			r.log(ctx).Errorf("Error occurred while retrieving interview result: %v", err)
+			return nil, nil, err
 		}
 		result.Result = resultBytes
 		res = append(res, result)
+		keys = append(keys, key)
 	}
 
 	if err := rows.Err(); err != nil {
-		r.logger.Errorf("Error occurred while iterating over interview result for position rows: %v", err)
-		return nil, 0, err
+		r.log(ctx).Errorf("Error occurred while iterating over interview result for position rows: %v", err)
+		return nil, nil, err
 	}
-	query = `
-		SELECT COUNT(*)
-		FROM interviews i
-		INNER JOIN user_interviews ui ON ui.interview_id = i.id
-		INNER JOIN positions p ON p.id = ui.position_id
-		WHERE p.public_id = $1
-	`
-	var totalCount int
-	err = r.db.QueryRow(ctx, query, publicID).Scan(&totalCount)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return res, 0, nil
-		}
-		r.logger.Errorf("Error occurred while retrieving position count: %v", err)
-		return nil, 0, err
+
+	hasMore := len(res) > page.PageSize
+	if hasMore {
+		res, keys = res[:page.PageSize], keys[:page.PageSize]
+	}
+	if current != nil && current.Backward {
+		reverse(res)
+		reverse(keys)
 	}
-	return res, totalCount, nil
 
+	info := pageInfo(cursor{}, keys, hasMore, current, offset)
+	info.Count = count
+	return res, info, nil
 }
 
-func (r *positionRepository) GetPosition(publicID string) (*models.Position, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) GetPosition(ctx context.Context, publicID string) (*models.Position, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
-	query := `SELECT p.public_id, p.name, p.status, p.description, c.public_id, c.name, c.description, r.public_id, c.logo, array_agg(s.name)
-	FROM skills s
-	INNER JOIN position_skills ps ON ps.skill_id = s.id
-	INNER JOIN positions p ON ps.position_id = p.id
+	query := `SELECT p.public_id, p.name, p.status, p.status_changed_at, p.description, p.max_attempts, p.result_visibility, c.public_id, c.name, c.description, r.public_id, c.logo, array_remove(array_agg(s.name), NULL)
+	FROM positions p
 	INNER JOIN recruiters r ON p.recruiter_public_id = r.public_id
 	INNER JOIN users u ON r.public_id = u.public_id
 	INNER JOIN companies c ON r.company_public_id = c.public_id
-	WHERE p.public_id = $1
-	GROUP BY p.public_id, p.name, p.status, p.description, c.public_id, c.name, c.description, r.public_id, c.logo`
+	LEFT JOIN position_skills ps ON ps.position_id = p.id
+	LEFT JOIN skills s ON ps.skill_id = s.id
+	WHERE p.public_id = $1 AND p.deleted_at IS NULL
+	GROUP BY p.public_id, p.name, p.status, p.status_changed_at, p.description, p.max_attempts, p.result_visibility, c.public_id, c.name, c.description, r.public_id, c.logo`
 	res := &models.Position{
 		Company: &models.Company{},
 	}
@@ -185,7 +171,10 @@ func (r *positionRepository) GetPosition(publicID string) (*models.Position, err
 		&res.PublicID,
 		&res.Name,
 		&res.Status,
+		&res.StatusChangedAt,
 		&res.Description,
+		&res.MaxAttempts,
+		&res.ResultVisibility,
 		&res.Company.PublicID,
 		&res.Company.Name,
 		&res.Company.Description,
@@ -194,138 +183,363 @@ func (r *positionRepository) GetPosition(publicID string) (*models.Position, err
 		&res.Skills,
 	)
 	if err != nil {
-		r.logger.Errorf("Error occurred while getting position: %v", err)
-		return res, err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrPositionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while getting position: %v", err)
+		return nil, err
 	}
 	return res, nil
 }
 
-func (r *positionRepository) Exists(publicID string) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) Exists(ctx context.Context, publicID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
 	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM positions WHERE public_id = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM positions WHERE public_id = $1 AND deleted_at IS NULL)`
 
 	err := r.db.QueryRow(ctx, query, publicID).Scan(&exists)
 	if err != nil {
-		r.logger.Errorf("Error occurred while checking position existence: %v", err)
+		r.log(ctx).Errorf("Error occurred while checking position existence: %v", err)
 		return false, err
 	}
 
 	return exists, nil
 }
 
-func (r *positionRepository) CreatePosition(position *models.Position) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) GetPositionOwner(ctx context.Context, publicID string) (*models.PositionOwner, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `
+		SELECT r.public_id, r.company_public_id
+		FROM positions p
+		INNER JOIN recruiters r ON p.recruiter_public_id = r.public_id
+		WHERE p.public_id = $1
+	`
+	owner := &models.PositionOwner{}
+	err := r.db.QueryRow(ctx, query, publicID).Scan(&owner.RecruiterPublicID, &owner.CompanyPublicID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrPositionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while getting position owner: %v", err)
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+func (r *positionRepository) CreatePosition(ctx context.Context, position *models.Position) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
-	var id int64
+
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		r.logger.Errorf("Error occurred while starting transaction: %v", err)
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
 		return "", err
 	}
+	defer tx.Rollback(ctx)
 
-	// Insert the position into the positions table
-	insertPositionQuery := `INSERT INTO positions (description, name, status, recruiter_public_id)
-		VALUES ($1, $2, $3, $4) RETURNING public_id, id`
-	row := tx.QueryRow(ctx, insertPositionQuery, position.Description, position.Name, position.Status, position.RecruiterPublicID)
-	if err := row.Scan(&position.PublicID, &id); err != nil {
-		r.logger.Errorf("Error occurred while creating position: %v", err)
-		tx.Rollback(ctx)
+	if _, err := r.insertPosition(ctx, tx, position); err != nil {
 		return "", err
 	}
 
-	// Loop over the skills array
+	err = tx.Commit(ctx)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return "", err
+	}
+
+	return *position.PublicID, nil
+}
+
+// insertPosition creates a position with its skills in tx, setting its public
+// id, and returns its id.
+func (r *positionRepository) insertPosition(ctx context.Context, tx pgx.Tx, position *models.Position) (int, error) {
+	var id int
+	insertPositionQuery := `INSERT INTO positions (description, name, status, recruiter_public_id, max_attempts, result_visibility)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, '` + models.ResultVisibilityBreakdown + `')) RETURNING public_id, id`
+	row := tx.QueryRow(ctx, insertPositionQuery, position.Description, position.Name, position.Status, position.RecruiterPublicID, position.MaxAttempts, position.ResultVisibility)
+	if err := row.Scan(&position.PublicID, &id); err != nil {
+		r.log(ctx).Errorf("Error occurred while creating position: %v", err)
+		return 0, err
+	}
+
 	for _, skillName := range position.Skills {
-		// Check if the skill already exists in the database
-		var skillID int
-		query := `
-		SELECT id FROM skills WHERE name = $1
-		`
-		err := tx.QueryRow(ctx, query, skillName).Scan(&skillID)
+		if skillName == nil {
+			continue
+		}
+		skillID, err := getOrCreateSkill(ctx, tx, *skillName)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				// Skill doesn't exist, so insert it into the database
-				insertQuery := `
-				INSERT INTO skills (name) VALUES ($1)
-				RETURNING id
-				`
-				err = tx.QueryRow(ctx, insertQuery, skillName).Scan(&skillID)
-				if err != nil {
-					r.logger.Errorf("Error inserting new skill: %v", err)
-					return "", err
-				}
-			} else {
-				r.logger.Errorf("Error checking skill existence: %v", err)
-				return "", err
-			}
+			r.log(ctx).Errorf("Error occurred while resolving skill: %v", err)
+			return 0, err
 		}
 
 		insertQuery := `
-		INSERT INTO position_skills (position_id, skill_id) VALUES (
-			$1,
-			$2
-		)
+		INSERT INTO position_skills (position_id, skill_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
 		`
-		_, err = tx.Exec(ctx, insertQuery, id, skillID)
-		if err != nil {
-			r.logger.Errorf("Error adding skill to position: %v", err)
-			return "", nil
+		if _, err := tx.Exec(ctx, insertQuery, id, skillID); err != nil {
+			r.log(ctx).Errorf("Error adding skill to position: %v", err)
+			return 0, err
 		}
 	}
+	return id, nil
+}
 
-	err = tx.Commit(ctx)
+// ImportPositions creates positions together with their questions in a single
+// transaction, so a failing position leaves none of them behind. It returns the
+// public ids of the created positions.
+func (r *positionRepository) ImportPositions(ctx context.Context, positions []*models.PositionWithQuestions) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		r.logger.Errorf("Error occurred while committing transaction: %v", err)
-		return "", err
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	return *position.PublicID, nil
+	res := make([]string, 0, len(positions))
+	for i, p := range positions {
+		id, err := r.insertPosition(ctx, tx, &p.Position)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: %w", i+1, err)
+		}
+		if err := r.insertQuestions(ctx, tx, id, *p.PublicID, p.Questions); err != nil {
+			return nil, fmt.Errorf("position %d: %w", i+1, err)
+		}
+		res = append(res, *p.PublicID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return nil, err
+	}
+	return res, nil
 }
 
-func (r *positionRepository) UpdatePosition(position *models.Position) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+// UpdatePosition edits the set fields of a position. A status change only
+// applies while the position is still in status from, like UpdatePositionStatus.
+func (r *positionRepository) UpdatePosition(ctx context.Context, position *models.Position, from *int) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		r.logger.Errorf("Error occurred while starting transaction: %v", err)
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
 		return err
 	}
 
+	var id int
+	var status *int
+	err = tx.QueryRow(ctx, `SELECT id, status FROM positions WHERE public_id = $1 AND deleted_at IS NULL FOR UPDATE`, position.PublicID).Scan(&id, &status)
+	if err != nil {
+		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrPositionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while locking position: %v", err)
+		return err
+	}
+	if position.Status != nil && (from == nil || status == nil || *status != *from) {
+		tx.Rollback(ctx)
+		return models.ErrInvalidTransition
+	}
+
 	// Update the position in the positions table
 	updatePositionQuery := `
 		UPDATE positions
 		SET description = COALESCE($1, description),
 			name = COALESCE($2, name),
+			status_changed_at = CASE
+				WHEN $3::int IS NOT NULL AND $3::int <> status THEN now()
+				ELSE status_changed_at
+			END,
 			status = COALESCE($3, status),
-			recruiter_public_id = COALESCE($4, recruiter_public_id)
-		WHERE public_id = $5
+			recruiter_public_id = COALESCE($4, recruiter_public_id),
+			max_attempts = COALESCE($6, max_attempts),
+			result_visibility = COALESCE($7, result_visibility)
+		WHERE id = $5
 	`
-	_, err = tx.Exec(ctx, updatePositionQuery, position.Description, position.Name, position.Status, position.RecruiterPublicID, position.PublicID)
+	_, err = tx.Exec(ctx, updatePositionQuery, position.Description, position.Name, position.Status, position.RecruiterPublicID, id, position.MaxAttempts, position.ResultVisibility)
+	if err != nil {
+		tx.Rollback(ctx)
+		r.log(ctx).Errorf("Error occurred while updating position: %v", err)
+		return err
+	}
+
+	// A nil skills slice leaves the current skills untouched, an empty one clears them
+	if position.Skills != nil {
+		_, err = tx.Exec(ctx, `DELETE FROM position_skills WHERE position_id = $1`, id)
+		if err != nil {
+			r.log(ctx).Errorf("Error occurred while clearing position skills: %v", err)
+			tx.Rollback(ctx)
+			return err
+		}
+
+		for _, skillName := range position.Skills {
+			if skillName == nil {
+				continue
+			}
+			skillID, err := getOrCreateSkill(ctx, tx, *skillName)
+			if err != nil {
+				r.log(ctx).Errorf("Error occurred while resolving skill: %v", err)
+				tx.Rollback(ctx)
+				return err
+			}
+
+			insertQuery := `
+			INSERT INTO position_skills (position_id, skill_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			`
+			_, err = tx.Exec(ctx, insertQuery, id, skillID)
+			if err != nil {
+				r.log(ctx).Errorf("Error adding skill to position: %v", err)
+				tx.Rollback(ctx)
+				return err
+			}
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// getOrCreateSkill returns the id of the skill with the given name, inserting it when missing.
+func getOrCreateSkill(ctx context.Context, tx pgx.Tx, name string) (int, error) {
+	var skillID int
+	err := tx.QueryRow(ctx, `SELECT id FROM skills WHERE name = $1`, name).Scan(&skillID)
+	if err == nil {
+		return skillID, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+
+	err = tx.QueryRow(ctx, `INSERT INTO skills (name) VALUES ($1) RETURNING id`, name).Scan(&skillID)
+	if err != nil {
+		return 0, err
+	}
+	return skillID, nil
+}
+
+func (r *positionRepository) UpdatePositionStatus(ctx context.Context, publicID string, from, to int) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	// the status guard makes concurrent transitions from the same state fail instead of overwriting each other
+	query := `
+		UPDATE positions
+		SET status = $3, status_changed_at = now()
+		WHERE public_id = $1 AND status = $2
+	`
+	tag, err := r.db.Exec(ctx, query, publicID, from, to)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while updating position status: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrInvalidTransition
+	}
+
+	return nil
+}
+
+func (r *positionRepository) SoftDeletePosition(ctx context.Context, publicID string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `UPDATE positions SET deleted_at = now() WHERE public_id = $1 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, publicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while soft deleting position: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrPositionNotFound
+	}
+
+	return nil
+}
+
+func (r *positionRepository) RestorePosition(ctx context.Context, publicID string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `UPDATE positions SET deleted_at = NULL WHERE public_id = $1 AND deleted_at IS NOT NULL`
+	tag, err := r.db.Exec(ctx, query, publicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while restoring position: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrPositionNotFound
+	}
+
+	return nil
+}
+
+func (r *positionRepository) DeletePosition(ctx context.Context, publicID string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return err
+	}
+
+	var id int
+	err = tx.QueryRow(ctx, `SELECT id FROM positions WHERE public_id = $1 FOR UPDATE`, publicID).Scan(&id)
 	if err != nil {
-		r.logger.Errorf("Error occurred while updating position: %v", err)
 		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrPositionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while locking position: %v", err)
 		return err
 	}
 
+	// interviews cascade to user_interviews and videos, the position itself to position_skills
+	cleanup := []string{
+		`DELETE FROM questions WHERE position_id = $1`,
+		`DELETE FROM areas WHERE position_id = $1`,
+		`DELETE FROM interviews WHERE id IN (SELECT interview_id FROM user_interviews WHERE position_id = $1)`,
+		`DELETE FROM positions WHERE id = $1`,
+	}
+	for _, query := range cleanup {
+		if _, err := tx.Exec(ctx, query, id); err != nil {
+			r.log(ctx).Errorf("Error occurred while deleting position: %v", err)
+			tx.Rollback(ctx)
+			return err
+		}
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
-		r.logger.Errorf("Error occurred while committing transaction: %v", err)
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
 		return err
 	}
 
 	return nil
 }
 
-func (r *positionRepository) CreateSkillsForPosition(positionPublicID string, skills []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) CreateSkillsForPosition(ctx context.Context, positionPublicID string, skills []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		r.logger.Errorf("Error beginning transaction: %v", err)
+		r.log(ctx).Errorf("Error beginning transaction: %v", err)
 		return err
 	}
 
@@ -347,12 +561,12 @@ func (r *positionRepository) CreateSkillsForPosition(positionPublicID string, sk
 				err = tx.QueryRow(ctx, insertQuery, skillName).Scan(&skillID)
 				if err != nil {
 					tx.Rollback(ctx)
-					r.logger.Errorf("Error inserting new skill: %v", err)
+					r.log(ctx).Errorf("Error inserting new skill: %v", err)
 					return err
 				}
 			} else {
 				tx.Rollback(ctx)
-				r.logger.Errorf("Error checking skill existence: %v", err)
+				r.log(ctx).Errorf("Error checking skill existence: %v", err)
 				return err
 			}
 		}
@@ -367,27 +581,27 @@ func (r *positionRepository) CreateSkillsForPosition(positionPublicID string, sk
 		_, err = tx.Exec(ctx, insertQuery, positionPublicID, skillID)
 		if err != nil {
 			tx.Rollback(ctx)
-			r.logger.Errorf("Error adding skill to position: %v", err)
+			r.log(ctx).Errorf("Error adding skill to position: %v", err)
 			return err
 		}
 	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		r.logger.Errorf("Error committing transaction: %v", err)
+		r.log(ctx).Errorf("Error committing transaction: %v", err)
 		return err
 	}
 
 	return nil
 }
 
-func (r *positionRepository) DeleteSkillsFromPosition(positionPublicID string, skills []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) DeleteSkillsFromPosition(ctx context.Context, positionPublicID string, skills []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		r.logger.Errorf("Error beginning transaction: %v", err)
+		r.log(ctx).Errorf("Error beginning transaction: %v", err)
 		return err
 	}
 
@@ -403,7 +617,7 @@ func (r *positionRepository) DeleteSkillsFromPosition(positionPublicID string, s
 			if errors.Is(pgx.ErrNoRows, err) {
 				continue
 			}
-			r.logger.Errorf("Error retrieving skill ID: %v", err)
+			r.log(ctx).Errorf("Error retrieving skill ID: %v", err)
 			tx.Rollback(ctx)
 			return err
 		}
@@ -416,367 +630,476 @@ func (r *positionRepository) DeleteSkillsFromPosition(positionPublicID string, s
 		`
 		_, err = tx.Exec(ctx, deleteQuery, positionPublicID, skillID)
 		if err != nil {
-			r.logger.Errorf("Error deleting skill from position: %v", err)
+			r.log(ctx).Errorf("Error deleting skill from position: %v", err)
 		}
 	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		r.logger.Errorf("Error committing transaction: %v", err)
+		r.log(ctx).Errorf("Error committing transaction: %v", err)
 		return err
 	}
 
 	return nil
 }
 
-func (r *positionRepository) GetPositionsByCompany(companyID string, pageNum int, pageSize int, search string) ([]models.Position, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
-	defer cancel()
-	countQuery := `
-		SELECT
-			COUNT(*)
-		FROM
-			positions p
-		INNER JOIN
-			recruiters r ON p.recruiter_public_id = r.public_id
-		WHERE
-			r.company_public_id = $1
-			AND (p.name ILIKE $2 OR p.description ILIKE $2)
-	`
-
-	query := `
-		SELECT
-			p.public_id,
-			p.description,
-			p.name,
-			p.status,
-			p.recruiter_public_id
-		FROM
-			positions p
-		INNER JOIN
-			recruiters r ON p.recruiter_public_id = r.public_id
-		WHERE
-			r.company_public_id = $1
-			AND (p.name ILIKE $2 OR p.description ILIKE $2)
-		GROUP BY 
-			p.id, 
-			p.public_id,
-			p.description,
-			p.name,
-			p.status,
-			p.recruiter_public_id
-		ORDER BY
-			p.id ASC
-		LIMIT $3 OFFSET $4
-	`
-
-	// Calculate the offset based on the page number and page size
-	offset := (pageNum - 1) * pageSize
+func (r *positionRepository) GetPositionsByCompany(ctx context.Context, companyID string, page *models.Pagination, filter *models.PositionFilter) ([]models.Position, *models.PageInfo, error) {
+	f := *filter
+	f.CompanyPublicID = companyID
+	return r.listPositions(ctx, &f, page)
+}
 
-	// Format the search query by adding wildcard characters
-	searchQuery := "%" + search + "%"
+func (r *positionRepository) GetPositionsByRecruiter(ctx context.Context, recruiterID string, page *models.Pagination, filter *models.PositionFilter) ([]models.Position, *models.PageInfo, error) {
+	f := *filter
+	f.RecruiterPublicID = recruiterID
+	return r.listPositions(ctx, &f, page)
+}
 
-	// Retrieve the count of positions
-	var count int
-	err := r.db.QueryRow(ctx, countQuery, companyID, searchQuery).Scan(&count)
-	if err != nil {
-		r.logger.Errorf("Error retrieving positions by company: %v", err)
-		return nil, 0, err
+// loadPositionSkills fills the skills of a listed page in one query, keyed by
+// the position ids fetched alongside it.
+func (r *positionRepository) loadPositionSkills(ctx context.Context, positions []models.Position, keys []keysetKey) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	ids := make([]int, len(keys))
+	byID := make(map[int]*models.Position, len(keys))
+	for i, key := range keys {
+		ids[i] = key.id
+		byID[key.id] = &positions[i]
 	}
 
-	rows, err := r.db.Query(ctx, query, companyID, searchQuery, pageSize, offset)
+	query := `SELECT ps.position_id, array_agg(s.name ORDER BY s.name)
+		FROM position_skills ps
+		INNER JOIN skills s ON s.id = ps.skill_id
+		WHERE ps.position_id = ANY($1)
+		GROUP BY ps.position_id`
+	rows, err := r.db.Query(ctx, query, ids)
 	if err != nil {
-		r.logger.Errorf("Error retrieving positions by company: %v", err)
-		return nil, 0, err
+		r.log(ctx).Errorf("Error retrieving position skills: %v", err)
+		return err
 	}
 	defer rows.Close()
 
-	positions := []models.Position{}
 	for rows.Next() {
-		var position models.Position
-		err := rows.Scan(
-			&position.PublicID,
-			&position.Description,
-			&position.Name,
-			&position.Status,
-			&position.RecruiterPublicID,
-		)
-		if err != nil {
-			r.logger.Errorf("Error retrieving positions by company: %v", err)
-			return nil, 0, err
-		}
-		query := `SELECT array_agg(s.name) from skills AS s
-		INNER JOIN position_skills ps ON ps.skill_id = s.id
-		INNER JOIN positions p ON ps.position_id = p.id
-		WHERE p.public_id = $1`
-		if position.PublicID != nil {
-			err = r.db.QueryRow(ctx, query, *position.PublicID).Scan(&position.Skills)
-			if err != nil {
-				r.logger.Errorf("Error retrieving positions by company: %v", err)
-				return nil, 0, err
-			}
+		var id int
+		var skills []*string
+		if err := rows.Scan(&id, &skills); err != nil {
+			r.log(ctx).Errorf("Error retrieving position skills: %v", err)
+			return err
 		}
-
-		positions = append(positions, position)
+		byID[id].Skills = skills
 	}
 
 	if err := rows.Err(); err != nil {
-		r.logger.Errorf("Error retrieving positions by company: %v", err)
-		return nil, 0, err
+		r.log(ctx).Errorf("Error occurred while iterating over position skills rows: %v", err)
+		return err
 	}
-
-	return positions, count, nil
+	return nil
 }
 
-func (r *positionRepository) GetPositionsByRecruiter(recruiterID string, pageNum int, pageSize int, search string) ([]models.Position, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+// listPositions backs every position listing with the shared filter builder.
+// Pages are selected by offset, or by keyset when a cursor is given.
+func (r *positionRepository) listPositions(ctx context.Context, filter *models.PositionFilter, page *models.Pagination) ([]models.Position, *models.PageInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
-	countQuery := `
-		SELECT
-			COUNT(*)
-		FROM
-			positions p
-		INNER JOIN
-			recruiters r ON p.recruiter_public_id = r.public_id
-		WHERE
-			r.public_id = $1
-			AND (p.name ILIKE $2 OR p.description ILIKE $2)
-	`
-
-	query := `
-		SELECT
-			p.public_id,
-			p.description,
-			p.name,
-			p.status,
-			p.recruiter_public_id
-		FROM
-			positions p
-		INNER JOIN
-			recruiters r ON p.recruiter_public_id = r.public_id
-		WHERE
-			r.public_id = $1
-			AND (p.name ILIKE $2 OR p.description ILIKE $2)
-		GROUP BY 
-			p.id, 
-			p.public_id,
-			p.description,
-			p.name,
-			p.status,
-			p.recruiter_public_id
-		ORDER BY
-			p.id ASC
-		LIMIT $3 OFFSET $4
-	`
 
-	// Calculate the offset based on the page number and page size
-	offset := (pageNum - 1) * pageSize
+	q := newPositionQuery(filter)
 
-	// Format the search query by adding wildcard characters
-	searchQuery := "%" + search + "%"
+	var count *int
+	if page.WithCount {
+		var totalCount int
+		err := r.db.QueryRow(ctx, q.countSQL(), q.args...).Scan(&totalCount)
+		if err != nil {
+			r.log(ctx).Errorf("Error occurred while retrieving position count: %v", err)
+			return nil, nil, err
+		}
+		count = &totalCount
+	}
 
-	// Retrieve the count of positions
-	var count int
-	err := r.db.QueryRow(ctx, countQuery, recruiterID, searchQuery).Scan(&count)
-	if err != nil {
-		r.logger.Errorf("Error retrieving positions: %v", err)
-		return nil, 0, err
+	var current *cursor
+	offset := 0
+	if page.Cursor != "" {
+		c, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		if c.Sort != filter.Sort || c.Ascending != filter.Ascending {
+			return nil, nil, models.ErrInvalidCursor
+		}
+		current = c
+		q.after(c)
+	} else {
+		offset = (page.PageNum - 1) * page.PageSize
 	}
+	backward := current != nil && current.Backward
 
-	rows, err := r.db.Query(ctx, query, recruiterID, searchQuery, pageSize, offset)
+	rows, err := r.db.Query(ctx, q.selectSQL(page.PageSize+1, offset, backward), q.args...)
 	if err != nil {
-		r.logger.Errorf("Error retrieving positions: %v", err)
-		return nil, 0, err
+		r.log(ctx).Errorf("Error occurred while retrieving positions: %v", err)
+		return nil, nil, err
 	}
 	defer rows.Close()
 
 	positions := []models.Position{}
+	keys := []keysetKey{}
 	for rows.Next() {
-		var position models.Position
-		err := rows.Scan(
-			&position.PublicID,
-			&position.Description,
-			&position.Name,
-			&position.Status,
-			&position.RecruiterPublicID,
-		)
+		var key keysetKey
+		position, err := scanPosition(rows, &key.id, &key.value)
 		if err != nil {
-			r.logger.Errorf("Error retrieving positions: %v", err)
-			return nil, 0, err
-		}
-		query := `SELECT array_agg(s.name) from skills AS s
-		INNER JOIN position_skills ps ON ps.skill_id = s.id
-		INNER JOIN positions p ON ps.position_id = p.id
-		WHERE p.public_id = $1`
-		if position.PublicID != nil {
-			err = r.db.QueryRow(ctx, query, *position.PublicID).Scan(&position.Skills)
-			if err != nil {
-				r.logger.Errorf("Error retrieving positions: %v", err)
-				return nil, 0, err
-			}
+			r.log(ctx).Errorf("Error occurred while scanning position rows: %v", err)
+			return nil, nil, err
 		}
-
-		positions = append(positions, position)
+		positions = append(positions, *position)
+		keys = append(keys, key)
 	}
 
 	if err := rows.Err(); err != nil {
-		r.logger.Errorf("Error retrieving positions: %v", err)
-		return nil, 0, err
+		r.log(ctx).Errorf("Error occurred while iterating over position rows: %v", err)
+		return nil, nil, err
 	}
 
-	return positions, count, nil
+	hasMore := len(positions) > page.PageSize
+	if hasMore {
+		positions, keys = positions[:page.PageSize], keys[:page.PageSize]
+	}
+	if backward {
+		reverse(positions)
+		reverse(keys)
+	}
+
+	if err := r.loadPositionSkills(ctx, positions, keys); err != nil {
+		return nil, nil, err
+	}
+
+	info := pageInfo(q.cursor(), keys, hasMore, current, offset)
+	info.Count = count
+	return positions, info, nil
 }
 
-func (r *positionRepository) AddQuestionsToPosition(positionPublicID string, questions []*models.Question) ([]*models.Question, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) AddQuestionsToPosition(ctx context.Context, positionPublicID string, questions []*models.Question) ([]*models.Question, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		r.logger.Errorf("Error occurred while starting transaction: %v", err)
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
 		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	// Retrieve the position ID from the positions table based on the public ID
+	// Retrieve the position ID from the positions table based on the public ID;
+	// the row lock keeps ordinals of concurrently added questions apart
 	var positionID int
-	getPositionIDQuery := `SELECT id FROM positions WHERE public_id = $1`
+	getPositionIDQuery := `SELECT id FROM positions WHERE public_id = $1 AND deleted_at IS NULL FOR UPDATE`
 	err = tx.QueryRow(ctx, getPositionIDQuery, positionPublicID).Scan(&positionID)
 	if err != nil {
 		if errors.Is(pgx.ErrNoRows, err) {
-			tx.Rollback(ctx)
 			return nil, models.ErrPositionNotFound
 		}
-		r.logger.Errorf("Error retrieving position ID: %v", err)
-		tx.Rollback(ctx)
+		r.log(ctx).Errorf("Error retrieving position ID: %v", err)
+		return nil, err
+	}
+
+	if err := r.insertQuestions(ctx, tx, positionID, positionPublicID, questions); err != nil {
 		return nil, err
 	}
 
+	err = tx.Commit(ctx)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return nil, err
+	}
+
+	return questions, nil
+}
+
+// insertQuestions appends questions to the position in tx, setting their ids,
+// ordinals and skills.
+func (r *positionRepository) insertQuestions(ctx context.Context, tx pgx.Tx, positionID int, positionPublicID string, questions []*models.Question) error {
 	for _, question := range questions {
 		insertQuery := `
-		INSERT INTO questions (name, position_public_id, position_id, read_duration, answer_duration)
-		VALUES ($1, $2, $3, $4, $5) RETURNING public_id
+		INSERT INTO questions (name, position_public_id, position_id, read_duration, answer_duration, question_type,
+			difficulty, rubric, max_retakes, ordinal)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), COALESCE($9, 0),
+			(SELECT COALESCE(MAX(ordinal), 0) + 1 FROM questions WHERE position_id = $3))
+		RETURNING id, public_id, ordinal, max_retakes
 		`
-		err = tx.QueryRow(
+		err := tx.QueryRow(
 			ctx,
 			insertQuery,
 			question.Name,
 			positionPublicID,
 			positionID,
 			question.ReadDuration,
-			question.AnswerDuration).Scan(&question.PublicID)
+			question.AnswerDuration,
+			question.Type,
+			question.Difficulty,
+			question.Rubric,
+			question.MaxRetakes).Scan(&question.ID, &question.PublicID, &question.Ordinal, &question.MaxRetakes)
 		if err != nil {
-			r.logger.Errorf("Error adding question to position: %v", err)
-			tx.Rollback(ctx)
-			return nil, err
+			r.log(ctx).Errorf("Error adding question to position: %v", err)
+			return err
+		}
+		if err := r.setQuestionSkills(ctx, tx, question.ID, question.Skills); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	err = tx.Commit(ctx)
-	if err != nil {
-		r.logger.Errorf("Error occurred while committing transaction: %v", err)
-		return nil, err
+// setQuestionSkills replaces the skills of a question, creating missing skills.
+func (r *positionRepository) setQuestionSkills(ctx context.Context, tx pgx.Tx, questionID int, skills []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM question_skills WHERE question_id = $1`, questionID); err != nil {
+		r.log(ctx).Errorf("Error removing question skills: %v", err)
+		return err
+	}
+	if len(skills) == 0 {
+		return nil
 	}
 
-	return questions, nil
+	query := `INSERT INTO skills (name)
+		SELECT DISTINCT n FROM unnest($1::text[]) n
+		WHERE NOT EXISTS (SELECT 1 FROM skills WHERE name = n)`
+	if _, err := tx.Exec(ctx, query, skills); err != nil {
+		r.log(ctx).Errorf("Error inserting new skills: %v", err)
+		return err
+	}
+
+	query = `INSERT INTO question_skills (question_id, skill_id)
+		SELECT $1, MIN(id) FROM skills WHERE name = ANY($2) GROUP BY name
+		ON CONFLICT DO NOTHING`
+	if _, err := tx.Exec(ctx, query, questionID, skills); err != nil {
+		r.log(ctx).Errorf("Error adding skills to question: %v", err)
+		return err
+	}
+	return nil
 }
 
-func (r *positionRepository) GetPositionQuestions(positionPublicID string) ([]*models.Question, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+// GetPositionQuestions returns the questions of a position in interview order.
+func (r *positionRepository) GetPositionQuestions(ctx context.Context, positionPublicID string) ([]*models.Question, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
 	query := `
-		SELECT name, public_id, read_duration, answer_duration
-		FROM questions
-		WHERE position_public_id = $1
+		SELECT ` + questionColumns + `
+		FROM questions q
+		LEFT JOIN bank_questions b ON b.id = q.bank_question_id
+		WHERE q.position_public_id = $1
+		ORDER BY q.ordinal, q.id
 	`
 
 	rows, err := r.db.Query(ctx, query, positionPublicID)
 	if err != nil {
-		r.logger.Errorf("Error occurred while querying questions for position: %v", err)
+		r.log(ctx).Errorf("Error occurred while querying questions for position: %v", err)
 		return nil, err
 	}
 	defer rows.Close()
 
 	questions := []*models.Question{}
 	for rows.Next() {
-		var question models.Question
-		err := rows.Scan(
-			&question.Name,
-			&question.PublicID,
-			&question.ReadDuration,
-			&question.AnswerDuration,
-		)
+		question, err := scanQuestion(rows)
 		if err != nil {
-			r.logger.Errorf("Error scanning question row: %v", err)
+			r.log(ctx).Errorf("Error scanning question row: %v", err)
 			return nil, err
 		}
-		questions = append(questions, &question)
+		questions = append(questions, question)
 	}
 
 	if err = rows.Err(); err != nil {
-		r.logger.Errorf("Error occurred while iterating over question rows: %v", err)
+		r.log(ctx).Errorf("Error occurred while iterating over question rows: %v", err)
 		return nil, err
 	}
 
 	return questions, nil
 }
 
-func (r *positionRepository) CreateInterview(positionPublicID, candidatePublicID string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+// questionColumns are the columns scanned by scanQuestion, for questions q
+// left joined with their bank question b.
+const questionColumns = `q.name, q.public_id, q.read_duration, q.answer_duration, COALESCE(q.question_type, ''),
+	q.ordinal, COALESCE(q.difficulty, ''), COALESCE(q.rubric, ''), q.max_retakes,
+	ARRAY(SELECT s.name FROM question_skills qs INNER JOIN skills s ON s.id = qs.skill_id
+		WHERE qs.question_id = q.id ORDER BY s.name),
+	b.public_id, q.linked`
+
+func scanQuestion(row pgx.Row) (*models.Question, error) {
+	question := &models.Question{}
+	err := row.Scan(
+		&question.Name,
+		&question.PublicID,
+		&question.ReadDuration,
+		&question.AnswerDuration,
+		&question.Type,
+		&question.Ordinal,
+		&question.Difficulty,
+		&question.Rubric,
+		&question.MaxRetakes,
+		&question.Skills,
+		&question.BankQuestionPublicID,
+		&question.Linked,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// ReorderQuestions sets the interview order of a position's questions. The
+// public ids must list every question of the position exactly once.
+func (r *positionRepository) ReorderQuestions(ctx context.Context, positionPublicID string, questionPublicIDs []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
-	publicID := uuid.New().String()
-	var interviewID int
 
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		r.logger.Errorf("Error occurred while starting transaction: %v", err)
-		return "", err
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return err
 	}
+	defer tx.Rollback(ctx)
 
-	// Insert into interviews table
-	query := `
-		INSERT INTO interviews (public_id) VALUES ($1) RETURNING id
-	`
-
-	err = tx.QueryRow(ctx, query, publicID).Scan(&interviewID)
+	var positionID int
+	query := `SELECT id FROM positions WHERE public_id = $1 AND deleted_at IS NULL FOR UPDATE`
+	err = tx.QueryRow(ctx, query, positionPublicID).Scan(&positionID)
 	if err != nil {
-		r.logger.Errorf("Error occurred while creating interview: %v", err)
-		if txErr := tx.Rollback(ctx); txErr != nil {
-			r.logger.Warn("could not rollback", txErr)
-			return "", txErr
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrPositionNotFound
 		}
-		return "", err
+		r.log(ctx).Errorf("Error retrieving position ID: %v", err)
+		return err
 	}
 
-	// Insert into user_interviews table
-	query = `
-		INSERT INTO user_interviews (candidate_id, position_id, interview_id)
-		SELECT c.id, p.id, $1
-		FROM candidates c
-		INNER JOIN positions p ON p.public_id = $2
-		WHERE c.public_id = $3
-	`
+	var total, listed int
+	query = `SELECT COUNT(*), COUNT(*) FILTER (WHERE public_id = ANY($2::uuid[])) FROM questions WHERE position_id = $1`
+	if err := tx.QueryRow(ctx, query, positionID, questionPublicIDs).Scan(&total, &listed); err != nil {
+		r.log(ctx).Errorf("Error counting position questions: %v", err)
+		return err
+	}
+	if total != len(questionPublicIDs) || listed != total {
+		return models.ErrInvalidInput
+	}
+
+	query = `UPDATE questions q SET ordinal = o.ordinal
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(public_id, ordinal)
+		WHERE q.public_id = o.public_id AND q.position_id = $1`
+	if _, err := tx.Exec(ctx, query, positionID, questionPublicIDs); err != nil {
+		r.log(ctx).Errorf("Error reordering questions: %v", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+// CreateInterview schedules an interview that expires unless started within ttl.
+// A candidate who already has an active interview for the position gets that one
+// back with created set to false. maxAttempts applies to positions without their
+// own limit; a limit of 0 allows unlimited attempts.
+func (r *positionRepository) CreateInterview(ctx context.Context, positionPublicID, candidatePublicID string, ttl time.Duration, maxAttempts int) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
 
-	_, err = tx.Exec(ctx, query, interviewID, positionPublicID, candidatePublicID)
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return "", false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var positionID int
+	query := `SELECT id, COALESCE(max_attempts, $2) FROM positions WHERE public_id = $1 AND deleted_at IS NULL`
+	err = tx.QueryRow(ctx, query, positionPublicID, maxAttempts).Scan(&positionID, &maxAttempts)
 	if err != nil {
-		r.logger.Errorf("Error occurred while inserting into user_interviews: %v", err)
-		if txErr := tx.Rollback(ctx); txErr != nil {
-			r.logger.Warn("could not rollback", txErr)
-			return "", txErr
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, models.ErrPositionNotFound
 		}
-		return "", err
+		r.log(ctx).Errorf("Error occurred while retrieving position: %v", err)
+		return "", false, err
+	}
+
+	var candidateID int
+	err = tx.QueryRow(ctx, `SELECT id FROM candidates WHERE public_id = $1`, candidatePublicID).Scan(&candidateID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, models.ErrUserNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while retrieving candidate: %v", err)
+		return "", false, err
+	}
+
+	// serializes concurrent requests of the same candidate for the same position
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, $2)`, positionID, candidateID); err != nil {
+		r.log(ctx).Errorf("Error occurred while locking interview creation: %v", err)
+		return "", false, err
+	}
+
+	var attempts int
+	query = `SELECT
+			(SELECT i.public_id::text
+				FROM interviews i
+				INNER JOIN user_interviews ui ON ui.interview_id = i.id
+				WHERE ui.candidate_id = $1 AND ui.position_id = $2
+					AND i.status IN ($3, $4) AND (i.expires_at IS NULL OR i.expires_at > now())
+				ORDER BY i.id DESC
+				LIMIT 1),
+			(SELECT COUNT(*) FROM user_interviews WHERE candidate_id = $1 AND position_id = $2)`
+	var active *string
+	err = tx.QueryRow(ctx, query, candidateID, positionID, models.InterviewStatusScheduled, models.InterviewStatusInProgress).Scan(&active, &attempts)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while retrieving candidate interviews: %v", err)
+		return "", false, err
+	}
+	if active != nil {
+		return *active, false, nil
+	}
+	if maxAttempts > 0 && attempts >= maxAttempts {
+		return "", false, models.ErrAttemptsExceeded
+	}
+
+	var interviewID int
+	publicID := uuid.New().String()
+	query = `INSERT INTO interviews (public_id, expires_at) VALUES ($1, now() + $2::interval) RETURNING id`
+	err = tx.QueryRow(ctx, query, publicID, ttl).Scan(&interviewID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while creating interview: %v", err)
+		return "", false, err
+	}
+
+	query = `INSERT INTO user_interviews (candidate_id, position_id, interview_id) VALUES ($1, $2, $3)`
+	tag, err := tx.Exec(ctx, query, candidateID, positionID, interviewID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while inserting into user_interviews: %v", err)
+		return "", false, err
+	}
+	if tag.RowsAffected() != 1 {
+		r.log(ctx).Errorf("Interview %s was not linked to its candidate and position", publicID)
+		return "", false, models.ErrInternalServer
+	}
+
+	query = `INSERT INTO interview_questions (interview_id, question_public_id, ordinal, name, read_duration, answer_duration,
+			question_type, difficulty, rubric, max_retakes, skills)
+		SELECT $1, q.public_id, row_number() OVER (ORDER BY q.ordinal, q.id), q.name, q.read_duration, q.answer_duration,
+			q.question_type, q.difficulty, q.rubric, q.max_retakes,
+			ARRAY(SELECT s.name FROM question_skills qs INNER JOIN skills s ON s.id = qs.skill_id
+				WHERE qs.question_id = q.id ORDER BY s.name)
+		FROM questions q
+		WHERE q.position_id = $2`
+	if _, err := tx.Exec(ctx, query, interviewID, positionID); err != nil {
+		r.log(ctx).Errorf("Error occurred while snapshotting interview questions: %v", err)
+		return "", false, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		r.logger.Errorf("Error occurred while committing transaction: %v", err)
-		return "", err
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return "", false, err
 	}
 
-	return publicID, nil
+	return publicID, true, nil
 }
 
-func (r *positionRepository) DeleteQuestion(publicID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) DeleteQuestion(ctx context.Context, publicID string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
 	query := `
@@ -785,44 +1108,89 @@ func (r *positionRepository) DeleteQuestion(publicID string) error {
 
 	_, err := r.db.Exec(ctx, query, publicID)
 	if err != nil {
-		r.logger.Error("could not delete question", err)
+		r.log(ctx).Error("could not delete question", err)
 		return err
 	}
 
 	return nil
 }
 
-func (r *positionRepository) UpdateQuestion(q *models.Question) (*models.Question, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) UpdateQuestion(ctx context.Context, q *models.Question) (*models.Question, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// editing a question taken from the bank by reference detaches it from the bank
 	query := `
 		UPDATE questions
 		SET
 			name = COALESCE($2, name),
 			read_duration = COALESCE(NULLIF($3, 0), read_duration),
-			answer_duration = COALESCE(NULLIF($4, 0), answer_duration)
+			answer_duration = COALESCE(NULLIF($4, 0), answer_duration),
+			question_type = COALESCE(NULLIF($5, ''), question_type),
+			difficulty = COALESCE(NULLIF($6, ''), difficulty),
+			rubric = COALESCE(NULLIF($7, ''), rubric),
+			max_retakes = COALESCE($8, max_retakes),
+			linked = false
 			WHERE public_id = $1
-			RETURNING name, public_id, read_duration, answer_duration
+			RETURNING id
 			`
 
-	var updatedQuestion models.Question
-	err := r.db.QueryRow(ctx, query, q.PublicID, q.Name, q.ReadDuration, q.AnswerDuration).Scan(
-		&updatedQuestion.Name,
-		&updatedQuestion.PublicID,
-		&updatedQuestion.ReadDuration,
-		&updatedQuestion.AnswerDuration,
-	)
+	var id int
+	err = tx.QueryRow(ctx, query, q.PublicID, q.Name, q.ReadDuration, q.AnswerDuration, q.Type, q.Difficulty, q.Rubric, q.MaxRetakes).Scan(&id)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while updating question: %v", err)
+		return nil, err
+	}
+	if q.Skills != nil {
+		if err := r.setQuestionSkills(ctx, tx, id, q.Skills); err != nil {
+			return nil, err
+		}
+	}
+
+	query = `SELECT ` + questionColumns + `
+		FROM questions q
+		LEFT JOIN bank_questions b ON b.id = q.bank_question_id
+		WHERE q.id = $1`
+	updatedQuestion, err := scanQuestion(tx.QueryRow(ctx, query, id))
 	if err != nil {
-		r.logger.Errorf("Error occurred while updating question: %v", err)
+		r.log(ctx).Errorf("Error occurred while retrieving updated question: %v", err)
 		return nil, err
 	}
 
-	return &updatedQuestion, nil
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return nil, err
+	}
+	return updatedQuestion, nil
+}
+
+func (r *positionRepository) GetQuestionPositionPublicID(ctx context.Context, questionPublicID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	var positionPublicID string
+	query := `SELECT position_public_id FROM questions WHERE public_id = $1`
+	err := r.db.QueryRow(ctx, query, questionPublicID).Scan(&positionPublicID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", models.ErrQuestionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while getting question position: %v", err)
+		return "", err
+	}
+
+	return positionPublicID, nil
 }
 
-func (r *positionRepository) QuestionExists(publicId string) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.TimeOut)
+func (r *positionRepository) QuestionExists(ctx context.Context, publicId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
 	defer cancel()
 	var exists bool
 
@@ -834,7 +1202,7 @@ func (r *positionRepository) QuestionExists(publicId string) (bool, error) {
 
 	err := r.db.QueryRow(ctx, query, publicId).Scan(&exists)
 	if err != nil {
-		r.logger.Error("could not check question existence", err)
+		r.log(ctx).Error("could not check question existence", err)
 		return false, err
 	}
 
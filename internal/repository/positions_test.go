@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository/migrations"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+)
+
+// testDatabaseEnv names a disposable database the repository tests migrate and write to.
+const testDatabaseEnv = "TEST_DATABASE_URL"
+
+// testDBConf bounds every query of the repository tests.
+var testDBConf = &config.DBConf{TimeOut: 10 * time.Second}
+
+// listingPageSizes are the page sizes the listing query count is compared across.
+var listingPageSizes = []int{1, 5, 25}
+
+// queryCounter counts the statements sent by the pool's connections through
+// the pgx logger, which is called once per finished Query, QueryRow and Exec.
+type queryCounter struct {
+	n int64
+}
+
+func (q *queryCounter) Log(_ context.Context, _ pgx.LogLevel, msg string, _ map[string]interface{}) {
+	switch msg {
+	case "Query", "Exec", "SendBatch", "CopyFrom":
+		atomic.AddInt64(&q.n, 1)
+	}
+}
+
+func (q *queryCounter) reset() { atomic.StoreInt64(&q.n, 0) }
+
+func (q *queryCounter) count() int64 { return atomic.LoadInt64(&q.n) }
+
+// openTestDB connects to the test database with a query counter and migrates
+// it, skipping the test when no database is configured.
+func openTestDB(tb testing.TB) (*pgxpool.Pool, *queryCounter) {
+	tb.Helper()
+	dsn := os.Getenv(testDatabaseEnv)
+	if dsn == "" {
+		tb.Skipf("%s is not set", testDatabaseEnv)
+	}
+	ctx := context.Background()
+
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	counter := &queryCounter{}
+	poolConfig.ConnConfig.Logger = counter
+	poolConfig.ConnConfig.LogLevel = pgx.LogLevelInfo
+	db, err := pgxpool.ConnectConfig(ctx, poolConfig)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(db.Close)
+
+	logger := zap.NewNop().Sugar()
+	migrator, err := migrations.New(db, logger)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		tb.Fatal(err)
+	}
+	return db, counter
+}
+
+// setupRecruiter creates a company with one recruiter and returns the
+// recruiter's public id. Its positions are removed with it on cleanup.
+func setupRecruiter(tb testing.TB, db *pgxpool.Pool) string {
+	tb.Helper()
+	ctx := context.Background()
+
+	recruiterPublicID := uuid.NewString()
+	companyPublicID := uuid.NewString()
+	setup := []string{
+		`INSERT INTO users (public_id, first_name) VALUES ($1, 'Test')`,
+		`INSERT INTO companies (public_id, name) VALUES ($2, 'Test')`,
+		`INSERT INTO recruiters (public_id, company_public_id) VALUES ($1, $2)`,
+	}
+	for _, query := range setup {
+		if _, err := db.Exec(ctx, query, recruiterPublicID, companyPublicID); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	tb.Cleanup(func() {
+		// positions cascade from the recruiter, and the recruiter from the user
+		db.Exec(context.Background(), `DELETE FROM users WHERE public_id = $1`, recruiterPublicID)
+		db.Exec(context.Background(), `DELETE FROM companies WHERE public_id = $1`, companyPublicID)
+	})
+	return recruiterPublicID
+}
+
+// setupListing migrates the test database and creates a recruiter with the
+// given number of positions, two skills each. Everything is removed on cleanup.
+func setupListing(tb testing.TB, positions int) (*positionRepository, *queryCounter, string) {
+	tb.Helper()
+	db, counter := openTestDB(tb)
+	recruiterPublicID := setupRecruiter(tb, db)
+	ctx := context.Background()
+
+	repo := &positionRepository{db: db, cfg: testDBConf, logger: zap.NewNop().Sugar()}
+	for i := 0; i < positions; i++ {
+		name := fmt.Sprintf("Listing %d", i)
+		status := models.PositionStatusOpen
+		skillA, skillB := "Go", fmt.Sprintf("Listing skill %d", i%3)
+		_, err := repo.CreatePosition(ctx, &models.Position{
+			Name:              &name,
+			Status:            &status,
+			RecruiterPublicID: &recruiterPublicID,
+			Skills:            []*string{&skillA, &skillB},
+		})
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return repo, counter, recruiterPublicID
+}
+
+// TestListPositionsQueryCount checks that loading a page of positions with their
+// skills takes the same number of queries whatever the page size.
+func TestListPositionsQueryCount(t *testing.T) {
+	repo, counter, recruiterPublicID := setupListing(t, listingPageSizes[len(listingPageSizes)-1])
+	ctx := context.Background()
+
+	for _, withCount := range []bool{false, true} {
+		var want int64 = -1
+		for _, size := range listingPageSizes {
+			counter.reset()
+			page := &models.Pagination{PageNum: 1, PageSize: size, WithCount: withCount}
+			positions, _, err := repo.GetPositionsByRecruiter(ctx, recruiterPublicID, page, &models.PositionFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(positions) != size {
+				t.Fatalf("page size %d: got %d positions", size, len(positions))
+			}
+			for _, p := range positions {
+				if len(p.Skills) != 2 {
+					t.Fatalf("page size %d: position %s has %d skills, want 2", size, *p.PublicID, len(p.Skills))
+				}
+			}
+
+			got := counter.count()
+			if want == -1 {
+				want = got
+			}
+			if got != want {
+				t.Errorf("with count %v: page size %d took %d queries, page size %d took %d", withCount, size, got, listingPageSizes[0], want)
+			}
+		}
+	}
+}
+
+// BenchmarkListPositions reports the queries a listing page takes per page size.
+func BenchmarkListPositions(b *testing.B) {
+	repo, counter, recruiterPublicID := setupListing(b, listingPageSizes[len(listingPageSizes)-1])
+	ctx := context.Background()
+
+	for _, size := range listingPageSizes {
+		b.Run(fmt.Sprintf("page_size=%d", size), func(b *testing.B) {
+			counter.reset()
+			for i := 0; i < b.N; i++ {
+				page := &models.Pagination{PageNum: 1, PageSize: size}
+				if _, _, err := repo.GetPositionsByRecruiter(ctx, recruiterPublicID, page, &models.PositionFilter{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(counter.count())/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/Zhiyenbek/sp-positions-main-service/config"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -8,35 +11,88 @@ import (
 )
 
 type PositionRepository interface {
-	GetAllPositions(search string, pageNum, pageSize int) ([]models.Position, int, error)
-	GetPositionInterviews(publicID string, pageNum int, pageSize int) ([]models.InterviewResults, int, error)
-	Exists(publicID string) (bool, error)
-	GetPosition(publicID string) (*models.Position, error)
-	CreatePosition(position *models.Position) (string, error)
-	CreateSkillsForPosition(positionPublicID string, skills []string) error
-	DeleteSkillsFromPosition(positionPublicID string, skills []string) error
-	GetPositionsByCompany(companyID string, pageNum int, pageSize int, search string) ([]models.Position, int, error)
-	GetPositionsByRecruiter(recruiterID string, pageNum int, pageSize int, search string) ([]models.Position, int, error)
-	AddQuestionsToPosition(positionPublicID string, questions []*models.Question) ([]*models.Question, error)
-	GetPositionQuestions(positionPublicID string) ([]*models.Question, error)
-	CreateInterview(positionPublicID, candidatePublicID string) (string, error)
-	DeleteQuestion(publicID string) error
-	UpdateQuestion(q *models.Question) (*models.Question, error)
-	QuestionExists(publicId string) (bool, error)
+	GetAllPositions(ctx context.Context, filter *models.PositionFilter, page *models.Pagination) ([]models.Position, *models.PageInfo, error)
+	GetPositionInterviews(ctx context.Context, publicID string, filter *models.InterviewFilter, page *models.Pagination) ([]models.InterviewResults, *models.PageInfo, error)
+	Exists(ctx context.Context, publicID string) (bool, error)
+	GetPosition(ctx context.Context, publicID string) (*models.Position, error)
+	GetPositionOwner(ctx context.Context, publicID string) (*models.PositionOwner, error)
+	CreatePosition(ctx context.Context, position *models.Position) (string, error)
+	ImportPositions(ctx context.Context, positions []*models.PositionWithQuestions) ([]string, error)
+	UpdatePosition(ctx context.Context, position *models.Position, from *int) error
+	UpdatePositionStatus(ctx context.Context, publicID string, from, to int) error
+	SoftDeletePosition(ctx context.Context, publicID string) error
+	RestorePosition(ctx context.Context, publicID string) error
+	DeletePosition(ctx context.Context, publicID string) error
+	CreateSkillsForPosition(ctx context.Context, positionPublicID string, skills []string) error
+	DeleteSkillsFromPosition(ctx context.Context, positionPublicID string, skills []string) error
+	GetPositionsByCompany(ctx context.Context, companyID string, page *models.Pagination, filter *models.PositionFilter) ([]models.Position, *models.PageInfo, error)
+	GetPositionsByRecruiter(ctx context.Context, recruiterID string, page *models.Pagination, filter *models.PositionFilter) ([]models.Position, *models.PageInfo, error)
+	AddQuestionsToPosition(ctx context.Context, positionPublicID string, questions []*models.Question) ([]*models.Question, error)
+	GetPositionQuestions(ctx context.Context, positionPublicID string) ([]*models.Question, error)
+	CreateInterview(ctx context.Context, positionPublicID, candidatePublicID string, ttl time.Duration, maxAttempts int) (string, bool, error)
+	DeleteQuestion(ctx context.Context, publicID string) error
+	UpdateQuestion(ctx context.Context, q *models.Question) (*models.Question, error)
+	ReorderQuestions(ctx context.Context, positionPublicID string, questionPublicIDs []string) error
+	GetRubric(ctx context.Context, positionPublicID string) (*models.Rubric, error)
+	UpdateRubric(ctx context.Context, positionPublicID string, rubric *models.Rubric, defaults *config.EvaluationConf) error
+	QuestionExists(ctx context.Context, publicId string) (bool, error)
+	GetQuestionPositionPublicID(ctx context.Context, questionPublicID string) (string, error)
 }
 
 type CompanyRepository interface {
-	GetCompanyByRecruiterPublicID(recruiterPublicID string) (*models.Company, error)
+	GetCompanyByRecruiterPublicID(ctx context.Context, recruiterPublicID string) (*models.Company, error)
+}
+
+type InterviewRepository interface {
+	GetInterview(ctx context.Context, publicID string) (*models.Interview, error)
+	GetInterviewQuestions(ctx context.Context, publicID string) ([]*models.Question, error)
+	StartInterview(ctx context.Context, publicID string, duration time.Duration) error
+	FinishInterview(ctx context.Context, publicID, from, to string) error
+	ExpireInterviews(ctx context.Context) (int64, error)
+	SaveResults(ctx context.Context, publicID string, result *models.Result, defaults *config.EvaluationConf) (int, error)
+	ScoreInterviews(ctx context.Context, defaults *config.EvaluationConf) (int64, error)
+	GetInterviewRubric(ctx context.Context, publicID string) (*models.Rubric, error)
+	SaveAnswer(ctx context.Context, answer *models.Answer) error
+	GetAnswers(ctx context.Context, interviewPublicIDs []string) ([]*models.Answer, error)
+	GetLeaderboard(ctx context.Context, positionPublicID string, filter *models.LeaderboardFilter, page *models.Pagination) ([]*models.LeaderboardEntry, int, error)
+	GetPositionStats(ctx context.Context, positionPublicID string, buckets int) (*models.PositionStats, error)
+	GetResults(ctx context.Context, interviewPublicID, positionPublicID string) ([]models.InterviewResults, error)
+	SaveDecision(ctx context.Context, decision *models.Decision) error
+	GetDecisions(ctx context.Context, publicID string) ([]*models.Decision, error)
+	CreateNote(ctx context.Context, note *models.Note) error
+	UpdateNote(ctx context.Context, note *models.Note) error
+	GetNotes(ctx context.Context, publicID string) ([]*models.Note, error)
+	GetCandidateInterviews(ctx context.Context, candidatePublicID string, page *models.Pagination) ([]*models.CandidateInterview, int, error)
+	GetCandidateApplications(ctx context.Context, candidatePublicID string, defaultMaxAttempts int, page *models.Pagination) ([]*models.Application, int, error)
+}
+
+type SkillRepository interface {
+	MergeSkills(ctx context.Context, from, into string) error
+}
+
+type QuestionBankRepository interface {
+	CreateBankQuestion(ctx context.Context, q *models.BankQuestion) (*models.BankQuestion, error)
+	GetBankQuestions(ctx context.Context, companyPublicID string, filter *models.BankFilter, page *models.Pagination) ([]*models.BankQuestion, int, error)
+	UpdateBankQuestion(ctx context.Context, q *models.BankQuestion) (*models.BankQuestion, error)
+	DeleteBankQuestion(ctx context.Context, publicID string) error
+	GetBankQuestionCompany(ctx context.Context, publicID string) (string, error)
+	AttachBankQuestions(ctx context.Context, positionPublicID string, attachments []models.BankAttachment) ([]*models.Question, error)
 }
 
 type Repository struct {
 	PositionRepository
 	CompanyRepository
+	SkillRepository
+	InterviewRepository
+	QuestionBankRepository
 }
 
 func New(db *pgxpool.Pool, cfg *config.Configs, log *zap.SugaredLogger) *Repository {
 	return &Repository{
-		PositionRepository: NewPositionRepository(db, cfg.DB, log),
-		CompanyRepository:  NewCompanyRepository(db, cfg.DB, log),
+		PositionRepository:     NewPositionRepository(db, cfg.DB, log),
+		CompanyRepository:      NewCompanyRepository(db, cfg.DB, log),
+		SkillRepository:        NewSkillRepository(db, cfg.DB, log),
+		InterviewRepository:    NewInterviewRepository(db, cfg.DB, log),
+		QuestionBankRepository: NewQuestionBankRepository(db, cfg.DB, log),
 	}
 }
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/jackc/pgx/v4"
+)
+
+// SaveDecision sets the recruiter decision on a submitted or evaluated interview
+// and records the change. Repeating the current decision records nothing.
+func (r *interviewRepository) SaveDecision(ctx context.Context, decision *models.Decision) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		interviewID int
+		status      string
+	)
+	query := `SELECT id, status, decision FROM interviews WHERE public_id = $1 FOR UPDATE`
+	err = tx.QueryRow(ctx, query, decision.InterviewPublicID).Scan(&interviewID, &status, &decision.PreviousDecision)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrInterviewNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while retrieving interview: %v", err)
+		return err
+	}
+	if status != models.InterviewStatusSubmitted && status != models.InterviewStatusEvaluated {
+		return models.ErrInterviewNotDone
+	}
+	if decision.PreviousDecision != nil && *decision.PreviousDecision == decision.Decision {
+		query = `SELECT decided_at FROM interviews WHERE id = $1`
+		if err := tx.QueryRow(ctx, query, interviewID).Scan(&decision.CreatedAt); err != nil {
+			r.log(ctx).Errorf("Error occurred while retrieving interview decision: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	query = `UPDATE interviews SET decision = $2, decided_at = now() WHERE id = $1 RETURNING decided_at`
+	if err := tx.QueryRow(ctx, query, interviewID, decision.Decision).Scan(&decision.CreatedAt); err != nil {
+		r.log(ctx).Errorf("Error occurred while updating interview decision: %v", err)
+		return err
+	}
+
+	query = `INSERT INTO interview_decisions (interview_id, decision, previous_decision, author_public_id, comment, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)`
+	_, err = tx.Exec(ctx, query, interviewID, decision.Decision, decision.PreviousDecision, decision.AuthorPublicID, decision.Comment, decision.CreatedAt)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while recording interview decision: %v", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetDecisions returns the decision history of an interview, oldest first.
+func (r *interviewRepository) GetDecisions(ctx context.Context, publicID string) ([]*models.Decision, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `SELECT i.public_id, d.decision, d.previous_decision, d.author_public_id, COALESCE(d.comment, ''), d.created_at
+		FROM interview_decisions d
+		INNER JOIN interviews i ON i.id = d.interview_id
+		WHERE i.public_id = $1
+		ORDER BY d.id`
+
+	rows, err := r.db.Query(ctx, query, publicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while querying interview decisions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	decisions := []*models.Decision{}
+	for rows.Next() {
+		decision := &models.Decision{}
+		err := rows.Scan(
+			&decision.InterviewPublicID,
+			&decision.Decision,
+			&decision.PreviousDecision,
+			&decision.AuthorPublicID,
+			&decision.Comment,
+			&decision.CreatedAt,
+		)
+		if err != nil {
+			r.log(ctx).Errorf("Error scanning interview decision row: %v", err)
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over interview decision rows: %v", err)
+		return nil, err
+	}
+	return decisions, nil
+}
+
+func (r *interviewRepository) CreateNote(ctx context.Context, note *models.Note) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `INSERT INTO interview_notes (interview_id, author_public_id, body)
+		SELECT id, $2, $3 FROM interviews WHERE public_id = $1
+		RETURNING public_id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, note.InterviewPublicID, note.AuthorPublicID, note.Body).Scan(&note.PublicID, &note.CreatedAt, &note.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrInterviewNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while creating interview note: %v", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateNote replaces the body of a note. Only its author may change it.
+func (r *interviewRepository) UpdateNote(ctx context.Context, note *models.Note) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	var author string
+	query := `SELECT n.author_public_id
+		FROM interview_notes n
+		INNER JOIN interviews i ON i.id = n.interview_id
+		WHERE n.public_id = $1 AND i.public_id = $2`
+	err := r.db.QueryRow(ctx, query, note.PublicID, note.InterviewPublicID).Scan(&author)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrNoteNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while retrieving interview note: %v", err)
+		return err
+	}
+	if author != note.AuthorPublicID {
+		return models.ErrPermissionDenied
+	}
+
+	query = `UPDATE interview_notes SET body = $2, updated_at = now()
+		WHERE public_id = $1
+		RETURNING created_at, updated_at`
+	if err := r.db.QueryRow(ctx, query, note.PublicID, note.Body).Scan(&note.CreatedAt, &note.UpdatedAt); err != nil {
+		r.log(ctx).Errorf("Error occurred while updating interview note: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetNotes returns the notes on an interview, oldest first.
+func (r *interviewRepository) GetNotes(ctx context.Context, publicID string) ([]*models.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	query := `SELECT n.public_id, i.public_id, n.author_public_id, n.body, n.created_at, n.updated_at
+		FROM interview_notes n
+		INNER JOIN interviews i ON i.id = n.interview_id
+		WHERE i.public_id = $1
+		ORDER BY n.id`
+
+	rows, err := r.db.Query(ctx, query, publicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while querying interview notes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []*models.Note{}
+	for rows.Next() {
+		note := &models.Note{}
+		err := rows.Scan(
+			&note.PublicID,
+			&note.InterviewPublicID,
+			&note.AuthorPublicID,
+			&note.Body,
+			&note.CreatedAt,
+			&note.UpdatedAt,
+		)
+		if err != nil {
+			r.log(ctx).Errorf("Error scanning interview note row: %v", err)
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over interview note rows: %v", err)
+		return nil, err
+	}
+	return notes, nil
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetRubric returns the pass threshold of a position and the weights of its
+// questions in interview order. Unset maximum scores and threshold are nil.
+func (r *positionRepository) GetRubric(ctx context.Context, positionPublicID string) (*models.Rubric, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	rubric := &models.Rubric{Questions: []models.RubricQuestion{}}
+	query := `SELECT pass_threshold FROM positions WHERE public_id = $1 AND deleted_at IS NULL`
+	if err := r.db.QueryRow(ctx, query, positionPublicID).Scan(&rubric.PassThreshold); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrPositionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while retrieving position threshold: %v", err)
+		return nil, err
+	}
+
+	query = `SELECT q.public_id, q.name, q.weight, q.max_score,
+			ARRAY(SELECT s.name FROM question_skills qs INNER JOIN skills s ON s.id = qs.skill_id
+				WHERE qs.question_id = q.id ORDER BY s.name)
+		FROM questions q
+		WHERE q.position_public_id = $1
+		ORDER BY q.ordinal, q.id`
+	rows, err := r.db.Query(ctx, query, positionPublicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while querying rubric questions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		q := models.RubricQuestion{}
+		if err := rows.Scan(&q.QuestionPublicID, &q.Question, &q.Weight, &q.MaxScore, &q.Skills); err != nil {
+			r.log(ctx).Errorf("Error scanning rubric question row: %v", err)
+			return nil, err
+		}
+		rubric.Questions = append(rubric.Questions, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over rubric question rows: %v", err)
+		return nil, err
+	}
+	return rubric, nil
+}
+
+// UpdateRubric changes the pass threshold of a position and the weights and
+// maximum scores of the listed questions, and rescores the position's evaluated
+// interviews in the same transaction.
+func (r *positionRepository) UpdateRubric(ctx context.Context, positionPublicID string, rubric *models.Rubric, defaults *config.EvaluationConf) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while starting transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var positionID int
+	query := `UPDATE positions SET pass_threshold = COALESCE($2, pass_threshold)
+		WHERE public_id = $1 AND deleted_at IS NULL
+		RETURNING id`
+	if err := tx.QueryRow(ctx, query, positionPublicID, rubric.PassThreshold).Scan(&positionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrPositionNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while updating position threshold: %v", err)
+		return err
+	}
+
+	query = `UPDATE questions
+		SET weight = COALESCE($3, weight), max_score = COALESCE($4, max_score)
+		WHERE public_id = $1 AND position_id = $2`
+	for _, q := range rubric.Questions {
+		tag, err := tx.Exec(ctx, query, q.QuestionPublicID, positionID, q.Weight, q.MaxScore)
+		if err != nil {
+			r.log(ctx).Errorf("Error occurred while updating question weight: %v", err)
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return models.ErrQuestionNotFound
+		}
+	}
+
+	if _, err := tx.Exec(ctx, rescoreQuery(`p.id = $6`), rescoreArgs(defaults, positionID)...); err != nil {
+		r.log(ctx).Errorf("Error occurred while rescoring position interviews: %v", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log(ctx).Errorf("Error occurred while committing transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetInterviewRubric returns the rubric an interview is scored with: its
+// snapshotted questions and skills with the current weights of the position's
+// questions. Questions deleted from the position since keep the default weight.
+func (r *interviewRepository) GetInterviewRubric(ctx context.Context, publicID string) (*models.Rubric, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	rubric := &models.Rubric{Questions: []models.RubricQuestion{}}
+	query := `SELECT p.pass_threshold
+		FROM interviews i
+		INNER JOIN user_interviews ui ON ui.interview_id = i.id
+		INNER JOIN positions p ON p.id = ui.position_id
+		WHERE i.public_id = $1`
+	if err := r.db.QueryRow(ctx, query, publicID).Scan(&rubric.PassThreshold); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrInterviewNotFound
+		}
+		r.log(ctx).Errorf("Error occurred while retrieving position threshold: %v", err)
+		return nil, err
+	}
+
+	query = `SELECT iq.question_public_id, iq.name, COALESCE(q.weight, 1), q.max_score, iq.skills
+		FROM interview_questions iq
+		INNER JOIN interviews i ON i.id = iq.interview_id
+		LEFT JOIN questions q ON q.public_id = iq.question_public_id
+		WHERE i.public_id = $1
+		ORDER BY iq.ordinal`
+	rows, err := r.db.Query(ctx, query, publicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while querying interview rubric: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		q := models.RubricQuestion{}
+		if err := rows.Scan(&q.QuestionPublicID, &q.Question, &q.Weight, &q.MaxScore, &q.Skills); err != nil {
+			r.log(ctx).Errorf("Error scanning interview rubric row: %v", err)
+			return nil, err
+		}
+		rubric.Questions = append(rubric.Questions, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over interview rubric rows: %v", err)
+		return nil, err
+	}
+	return rubric, nil
+}
+
+// ScoreInterviews scores the evaluated interviews that have no scoring yet, such
+// as the ones evaluated before scores were stored or before the scoring had
+// question type scores, and returns how many it scored.
+func (r *interviewRepository) ScoreInterviews(ctx context.Context, defaults *config.EvaluationConf) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tag, err := r.db.Exec(ctx, rescoreQuery(`i.scoring IS NULL OR NOT i.scoring ? 'tech_score'`), rescoreArgs(defaults)...)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while scoring unscored interviews: %v", err)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// rescoreQuery recomputes the scoring of the evaluated interviews matching
+// where, a condition on interviews i, user_interviews ui and positions p. Every
+// snapshotted question contributes its score in the results over its maximum,
+// capped at 1, times its current weight; questions missing from the results
+// count as 0. Skill and question type scores are computed the same way over
+// the questions assessing the skill or of the type; a question missing from
+// the results keeps its snapshotted type. The query takes rescoreArgs followed
+// by the parameters of where, from $6 on.
+func rescoreQuery(where string) string {
+	return `WITH targets AS (
+			SELECT i.id, i.results, COALESCE(p.pass_threshold, $2::float8) AS pass_threshold
+			FROM interviews i
+			INNER JOIN user_interviews ui ON ui.interview_id = i.id
+			INNER JOIN positions p ON p.id = ui.position_id
+			WHERE i.status = $3 AND i.results IS NOT NULL AND (` + where + `)
+		), ratios AS (
+			SELECT t.id, iq.skills, COALESCE(r.question_type, iq.question_type) AS question_type, COALESCE(q.weight, 1) AS weight,
+				LEAST(GREATEST(COALESCE(r.score, 0) / COALESCE(q.max_score, $1::int), 0), 1) AS ratio
+			FROM targets t
+			INNER JOIN interview_questions iq ON iq.interview_id = t.id
+			LEFT JOIN questions q ON q.public_id = iq.question_public_id
+			LEFT JOIN LATERAL (
+				SELECT MAX((x->>'score')::float8) AS score, MAX(x->>'question_type') AS question_type
+				FROM jsonb_array_elements(t.results->'questions') x
+				WHERE x->>'question_public_id' = iq.question_public_id::text
+			) r ON true
+		), totals AS (
+			SELECT id, round((SUM(weight * ratio) / SUM(weight) * 100)::numeric, 2)::float8 AS score,
+				round((SUM(weight * ratio) FILTER (WHERE question_type = $4)
+					/ SUM(weight) FILTER (WHERE question_type = $4) * 100)::numeric, 2)::float8 AS tech_score,
+				round((SUM(weight * ratio) FILTER (WHERE question_type = $5)
+					/ SUM(weight) FILTER (WHERE question_type = $5) * 100)::numeric, 2)::float8 AS soft_score
+			FROM ratios
+			GROUP BY id
+		), skill_totals AS (
+			SELECT id, skill, round((SUM(weight * ratio) / SUM(weight) * 100)::numeric, 2)::float8 AS score,
+				COUNT(*) AS questions
+			FROM ratios, unnest(skills) AS skill
+			GROUP BY id, skill
+		), skills AS (
+			SELECT id, jsonb_agg(jsonb_build_object('skill', skill, 'score', score, 'questions', questions) ORDER BY skill) AS skills
+			FROM skill_totals
+			GROUP BY id
+		)
+		UPDATE interviews i SET scoring = jsonb_build_object(
+			'score', COALESCE(tt.score, 0),
+			'passed', COALESCE(tt.score, 0) >= t.pass_threshold,
+			'pass_threshold', t.pass_threshold,
+			'tech_score', tt.tech_score,
+			'soft_score', tt.soft_score,
+			'skills', COALESCE(s.skills, '[]'::jsonb),
+			'scored_at', now())
+		FROM targets t
+		LEFT JOIN totals tt ON tt.id = t.id
+		LEFT JOIN skills s ON s.id = t.id
+		WHERE i.id = t.id`
+}
+
+// rescoreArgs returns the parameters of rescoreQuery: the configured maximum
+// question score and pass threshold, used where the rubric leaves them unset,
+// the status and question types scored, followed by args.
+func rescoreArgs(defaults *config.EvaluationConf, args ...interface{}) []interface{} {
+	return append([]interface{}{
+		defaults.MaxQuestionScore,
+		defaults.PassThreshold,
+		models.InterviewStatusEvaluated,
+		models.QuestionTypeTech,
+		models.QuestionTypeSoft,
+	}, args...)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// searchJoin exposes the search parameter as a prefix tsquery named q.query.
+// A search without any lexeme, such as an empty one or one of punctuation only,
+// yields a NULL query, which callers treat as "no filter".
+func searchJoin(param string) string {
+	return fmt.Sprintf(`CROSS JOIN (
+		SELECT to_tsquery('simple', string_agg(quote_literal(lexeme) || ':*', ' & ')) AS query
+		FROM unnest(to_tsvector('simple', %s::text))
+	) q`, param)
+}
+
+const (
+	searchFilter = `(q.query IS NULL OR p.search_vector @@ q.query)`
+
+	headlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=25, MinWords=10`
+
+	// searchColumns selects the rank and the highlighted name and description.
+	searchColumns = `ts_rank_cd(p.search_vector, q.query)::float8,
+		ts_headline('simple', coalesce(p.name, ''), q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'),
+		ts_headline('simple', coalesce(p.description, ''), q.query, '` + headlineOptions + `')`
+)
+
+func normalizeSearch(search string) string {
+	return strings.TrimSpace(search)
+}
+
+// searchResult receives the searchColumns of a row.
+type searchResult struct {
+	rank        *float64
+	name        *string
+	description *string
+}
+
+func (s *searchResult) dest() []interface{} {
+	return []interface{}{&s.rank, &s.name, &s.description}
+}
+
+func (s *searchResult) apply(position *models.Position) {
+	if s.rank == nil {
+		return
+	}
+	position.Rank = s.rank
+	position.Highlights = &models.PositionHighlights{
+		Name:        s.name,
+		Description: s.description,
+	}
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+)
+
+type skillRepository struct {
+	db     *pgxpool.Pool
+	cfg    *config.DBConf
+	logger *zap.SugaredLogger
+}
+
+func NewSkillRepository(db *pgxpool.Pool, cfg *config.DBConf, logger *zap.SugaredLogger) SkillRepository {
+	return &skillRepository{
+		db:     db,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *skillRepository) log(ctx context.Context) *zap.SugaredLogger {
+	return requestid.Logger(ctx, r.logger)
+}
+
+// MergeSkills moves every position and candidate from the source skill to the
+// target one and removes the source. A missing target is created by renaming the source.
+func (r *skillRepository) MergeSkills(ctx context.Context, from, into string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		r.log(ctx).Errorf("Error beginning transaction: %v", err)
+		return err
+	}
+
+	var fromID int
+	err = tx.QueryRow(ctx, `SELECT id FROM skills WHERE name = $1 FOR UPDATE`, from).Scan(&fromID)
+	if err != nil {
+		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrSkillNotFound
+		}
+		r.log(ctx).Errorf("Error retrieving skill ID: %v", err)
+		return err
+	}
+
+	var intoID int
+	err = tx.QueryRow(ctx, `SELECT id FROM skills WHERE name = $1 FOR UPDATE`, into).Scan(&intoID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		_, err = tx.Exec(ctx, `UPDATE skills SET name = $2 WHERE id = $1`, fromID, into)
+		if err != nil {
+			tx.Rollback(ctx)
+			r.log(ctx).Errorf("Error renaming skill: %v", err)
+			return err
+		}
+		return tx.Commit(ctx)
+	}
+	if err != nil {
+		tx.Rollback(ctx)
+		r.log(ctx).Errorf("Error retrieving skill ID: %v", err)
+		return err
+	}
+	if fromID == intoID {
+		tx.Rollback(ctx)
+		return nil
+	}
+
+	// links of the source skill are removed by the cascade on skills
+	queries := []string{
+		`INSERT INTO position_skills (position_id, skill_id)
+		SELECT position_id, $2 FROM position_skills WHERE skill_id = $1
+		ON CONFLICT DO NOTHING`,
+		`INSERT INTO candidate_skills (candidate_id, skill_id)
+		SELECT candidate_id, $2 FROM candidate_skills WHERE skill_id = $1
+		ON CONFLICT DO NOTHING`,
+		`INSERT INTO question_skills (question_id, skill_id)
+		SELECT question_id, $2 FROM question_skills WHERE skill_id = $1
+		ON CONFLICT DO NOTHING`,
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(ctx, query, fromID, intoID); err != nil {
+			tx.Rollback(ctx)
+			r.log(ctx).Errorf("Error merging skills: %v", err)
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM skills WHERE id = $1`, fromID); err != nil {
+		tx.Rollback(ctx)
+		r.log(ctx).Errorf("Error deleting merged skill: %v", err)
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		r.log(ctx).Errorf("Error committing transaction: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// positionInterviews joins the interviews of the position given as $1.
+const positionInterviews = `interviews i
+	INNER JOIN user_interviews ui ON ui.interview_id = i.id
+	INNER JOIN positions p ON p.id = ui.position_id AND p.public_id = $1`
+
+// jsonArray expands a JSONB value that should be an array, treating anything else as empty.
+func jsonArray(value string) string {
+	return `jsonb_array_elements(CASE WHEN jsonb_typeof(` + value + `) = 'array' THEN ` + value + ` ELSE '[]'::jsonb END)`
+}
+
+// normalizedEmotion is the SQL counterpart of models.NormalizeEmotion for the
+// text expression value.
+func normalizedEmotion(value string) string {
+	labels := make([]string, 0, len(models.EmotionAliases))
+	for label := range models.EmotionAliases {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	quote := func(s string) string { return `'` + strings.ReplaceAll(s, `'`, `''`) + `'` }
+	var b strings.Builder
+	b.WriteString(`CASE lower(trim(` + value + `))`)
+	for _, label := range labels {
+		b.WriteString(` WHEN ` + quote(label) + ` THEN ` + quote(models.EmotionAliases[label]))
+	}
+	b.WriteString(` ELSE lower(trim(` + value + `)) END`)
+	return b.String()
+}
+
+// GetPositionStats aggregates the interviews of a position in SQL, reading the
+// normalized scores from the stored scoring and emotions from the JSONB results.
+// The score histogram divides the range from 0 to 100 into the given number of
+// equal buckets. The average
+// answer duration is taken over the recorded length of the answers.
+func (r *interviewRepository) GetPositionStats(ctx context.Context, positionPublicID string, buckets int) (*models.PositionStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.TimeOut)
+	defer cancel()
+
+	stats := &models.PositionStats{
+		StatusCounts:     map[string]int{},
+		ScoreHistogram:   []models.ScoreBucket{},
+		Questions:        []models.QuestionStats{},
+		DominantEmotions: []models.EmotionShare{},
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT i.status, COUNT(*) FROM `+positionInterviews+` GROUP BY i.status`, positionPublicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while counting position interviews: %v", err)
+		return nil, err
+	}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			r.log(ctx).Errorf("Error scanning interview status row: %v", err)
+			return nil, err
+		}
+		stats.StatusCounts[status] = count
+		stats.InterviewCount += count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over interview status rows: %v", err)
+		return nil, err
+	}
+
+	query := `SELECT AVG(score), percentile_cont(0.5) WITHIN GROUP (ORDER BY score),
+			round((AVG(passed::int) * 100)::numeric, 2)::float8,
+			AVG(duration) FILTER (WHERE status IN ($2, $3)),
+			(SELECT AVG(a.duration)
+				FROM ` + positionInterviews + `
+				INNER JOIN answers a ON a.interview_id = i.id
+				WHERE i.status IN ($2, $3))
+		FROM (
+			SELECT i.status, (i.scoring->>'score')::float8 AS score, (i.scoring->>'passed')::boolean AS passed,
+				EXTRACT(EPOCH FROM i.finished_at - i.started_at)::float8 AS duration
+			FROM ` + positionInterviews + `
+		) s`
+	err = r.db.QueryRow(ctx, query, positionPublicID, models.InterviewStatusSubmitted, models.InterviewStatusEvaluated).Scan(
+		&stats.AverageScore,
+		&stats.MedianScore,
+		&stats.PassRate,
+		&stats.AverageInterviewDuration,
+		&stats.AverageAnswerDuration,
+	)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while aggregating position scores: %v", err)
+		return nil, err
+	}
+
+	query = `WITH scores AS (
+			SELECT (i.scoring->>'score')::float8 AS score
+			FROM ` + positionInterviews + `
+			WHERE i.scoring->>'score' IS NOT NULL
+		)
+		SELECT (b - 1) * 100.0 / $2::int, b * 100.0 / $2::int, COUNT(s.score)
+		FROM generate_series(1, $2::int) b
+		LEFT JOIN scores s ON LEAST(width_bucket(s.score, 0, 100, $2::int), $2::int) = b
+		GROUP BY b
+		ORDER BY b`
+	rows, err = r.db.Query(ctx, query, positionPublicID, buckets)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while building score histogram: %v", err)
+		return nil, err
+	}
+	for rows.Next() {
+		var bucket models.ScoreBucket
+		if err := rows.Scan(&bucket.From, &bucket.To, &bucket.Count); err != nil {
+			rows.Close()
+			r.log(ctx).Errorf("Error scanning score histogram row: %v", err)
+			return nil, err
+		}
+		stats.ScoreHistogram = append(stats.ScoreHistogram, bucket)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over score histogram rows: %v", err)
+		return nil, err
+	}
+
+	query = `SELECT COALESCE(q->>'question_public_id', ''), COALESCE(q->>'question', ''), COUNT(*),
+			AVG((q->>'score')::numeric)::float8,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY (q->>'score')::float8)
+		FROM ` + positionInterviews + `
+		CROSS JOIN LATERAL ` + jsonArray(`i.results->'questions'`) + ` q
+		GROUP BY 1, 2
+		ORDER BY 2`
+	rows, err = r.db.Query(ctx, query, positionPublicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while aggregating question scores: %v", err)
+		return nil, err
+	}
+	for rows.Next() {
+		var question models.QuestionStats
+		err := rows.Scan(&question.QuestionPublicID, &question.Question, &question.Answers, &question.MeanScore, &question.MedianScore)
+		if err != nil {
+			rows.Close()
+			r.log(ctx).Errorf("Error scanning question stats row: %v", err)
+			return nil, err
+		}
+		stats.Questions = append(stats.Questions, question)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over question stats rows: %v", err)
+		return nil, err
+	}
+
+	// like the emotions endpoint, overlapping or touching segments of the same
+	// emotion within an answer count once
+	query = `WITH segments AS (
+			SELECT i.id AS interview_id, q.n AS question, ` + normalizedEmotion(`e->>'emotion'`) + ` AS emotion,
+				COALESCE((e->>'exact_time')::float8, 0) AS start_at,
+				COALESCE((e->>'exact_time')::float8, 0) + COALESCE((e->>'duration')::float8, 0) AS end_at
+			FROM ` + positionInterviews + `
+			CROSS JOIN LATERAL ` + jsonArray(`i.results->'questions'`) + ` WITH ORDINALITY AS q(value, n)
+			CROSS JOIN LATERAL ` + jsonArray(`q.value->'emotion_results'`) + ` e
+			WHERE COALESCE((e->>'duration')::float8, 0) > 0
+		), islands AS (
+			SELECT *, SUM(starts) OVER (PARTITION BY interview_id, question, emotion ORDER BY start_at, end_at ROWS UNBOUNDED PRECEDING) AS island
+			FROM (
+				SELECT *, CASE WHEN start_at <= MAX(end_at) OVER (PARTITION BY interview_id, question, emotion ORDER BY start_at, end_at
+					ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) THEN 0 ELSE 1 END AS starts
+				FROM segments
+				WHERE emotion <> ''
+			) s
+		), merged AS (
+			SELECT emotion, MAX(end_at) - MIN(start_at) AS duration
+			FROM islands
+			GROUP BY interview_id, question, emotion, island
+		)
+		SELECT emotion, round(SUM(duration)::numeric, 2)::float8,
+			round((SUM(duration) * 100 / NULLIF(SUM(SUM(duration)) OVER (), 0))::numeric, 2)::float8
+		FROM merged
+		GROUP BY emotion
+		ORDER BY 2 DESC, 1
+		LIMIT 5`
+	rows, err = r.db.Query(ctx, query, positionPublicID)
+	if err != nil {
+		r.log(ctx).Errorf("Error occurred while aggregating emotions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var emotion models.EmotionShare
+		var share *float64
+		if err := rows.Scan(&emotion.Emotion, &emotion.Duration, &share); err != nil {
+			r.log(ctx).Errorf("Error scanning emotion row: %v", err)
+			return nil, err
+		}
+		if share != nil {
+			emotion.Share = *share
+		}
+		stats.DominantEmotions = append(stats.DominantEmotions, emotion)
+	}
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Errorf("Error occurred while iterating over emotion rows: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
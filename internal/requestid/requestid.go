@@ -0,0 +1,33 @@
+// Package requestid carries the id of an HTTP request through its context so
+// that every layer can tag its log lines with it.
+package requestid
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Header is the request and response header holding the id.
+const Header = "X-Request-ID"
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the id stored in ctx, or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// Logger returns logger tagged with the request id of ctx, if there is one.
+func Logger(ctx context.Context, logger *zap.SugaredLogger) *zap.SugaredLogger {
+	if id := FromContext(ctx); id != "" {
+		return logger.With("request_id", id)
+	}
+	return logger
+}
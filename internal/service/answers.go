@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// RegisterAnswer records the uploaded video of an answer while the interview is in progress.
+func (s *interviewsService) RegisterAnswer(ctx context.Context, answer *models.Answer) (*models.Answer, error) {
+	interview, err := s.interviewRepo.GetInterview(ctx, answer.InterviewPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Status != models.InterviewStatusInProgress {
+		return nil, models.ErrInterviewNotStarted
+	}
+	if interview.ExpiresAt != nil && !interview.ExpiresAt.After(time.Now()) {
+		return nil, models.ErrInterviewExpired
+	}
+
+	if err := s.interviewRepo.SaveAnswer(ctx, answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+func (s *interviewsService) GetAnswers(ctx context.Context, publicID string) ([]*models.Answer, error) {
+	if _, err := s.interviewRepo.GetInterview(ctx, publicID); err != nil {
+		return nil, err
+	}
+	return s.interviewRepo.GetAnswers(ctx, []string{publicID})
+}
+
+// attachAnswers fills the video link and transcript of every question result
+// from the answers registered for it. Results without a question_public_id are left as sent.
+func attachAnswers(interviews []*models.Interview, answers []*models.Answer) {
+	type key struct{ interview, question string }
+	byQuestion := make(map[key]*models.Answer, len(answers))
+	for _, a := range answers {
+		byQuestion[key{a.InterviewPublicID, a.QuestionPublicID}] = a
+	}
+
+	for _, interview := range interviews {
+		if interview.Result == nil {
+			continue
+		}
+		for i := range interview.Result.Questions {
+			q := &interview.Result.Questions[i]
+			a, ok := byQuestion[key{interview.PublicID, q.QuestionPublicID}]
+			if !ok {
+				continue
+			}
+			q.VideoLink = a.VideoLink
+			if a.Transcript != "" {
+				q.Answer = a.Transcript
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type authorizationService struct {
+	cfg           *config.Configs
+	logger        *zap.SugaredLogger
+	positionRepo  repository.PositionRepository
+	companyRepo   repository.CompanyRepository
+	interviewRepo repository.InterviewRepository
+	bankRepo      repository.QuestionBankRepository
+}
+
+func NewAuthorizationService(repo *repository.Repository, cfg *config.Configs, logger *zap.SugaredLogger) AuthorizationService {
+	return &authorizationService{
+		positionRepo:  repo.PositionRepository,
+		companyRepo:   repo.CompanyRepository,
+		interviewRepo: repo.InterviewRepository,
+		bankRepo:      repo.QuestionBankRepository,
+		cfg:           cfg,
+		logger:        logger,
+	}
+}
+
+// IsCompanyRecruiter allows any recruiter of the company that owns the position.
+// It is the ownership rule of every position mutation.
+func (a *authorizationService) IsCompanyRecruiter(ctx context.Context, recruiterPublicID, positionPublicID string) error {
+	owner, err := a.positionRepo.GetPositionOwner(ctx, positionPublicID)
+	if err != nil {
+		return err
+	}
+	if owner.RecruiterPublicID == recruiterPublicID {
+		return nil
+	}
+
+	company, err := a.companyRepo.GetCompanyByRecruiterPublicID(ctx, recruiterPublicID)
+	if err != nil {
+		if errors.Is(err, models.ErrCompanyDoesntExists) {
+			return models.ErrPermissionDenied
+		}
+		return err
+	}
+	if company.PublicID == nil || *company.PublicID != owner.CompanyPublicID {
+		return models.ErrPermissionDenied
+	}
+	return nil
+}
+
+// IsQuestionRecruiter allows recruiters of the company that owns the question's position.
+func (a *authorizationService) IsQuestionRecruiter(ctx context.Context, recruiterPublicID, questionPublicID string) error {
+	positionPublicID, err := a.positionRepo.GetQuestionPositionPublicID(ctx, questionPublicID)
+	if err != nil {
+		return err
+	}
+	return a.IsCompanyRecruiter(ctx, recruiterPublicID, positionPublicID)
+}
+
+// IsInterviewCandidate allows only the candidate the interview was created for.
+func (a *authorizationService) IsInterviewCandidate(ctx context.Context, candidatePublicID, interviewPublicID string) error {
+	interview, err := a.interviewRepo.GetInterview(ctx, interviewPublicID)
+	if err != nil {
+		return err
+	}
+	if interview.CandidatePublicID != candidatePublicID {
+		return models.ErrPermissionDenied
+	}
+	return nil
+}
+
+// IsInterviewRecruiter allows the recruiters of the company that owns the interview's position.
+func (a *authorizationService) IsInterviewRecruiter(ctx context.Context, recruiterPublicID, interviewPublicID string) error {
+	interview, err := a.interviewRepo.GetInterview(ctx, interviewPublicID)
+	if err != nil {
+		return err
+	}
+	return a.IsCompanyRecruiter(ctx, recruiterPublicID, interview.PositionPublicID)
+}
+
+// IsCompanyMember allows the recruiters of the company.
+func (a *authorizationService) IsCompanyMember(ctx context.Context, recruiterPublicID, companyPublicID string) error {
+	company, err := a.companyRepo.GetCompanyByRecruiterPublicID(ctx, recruiterPublicID)
+	if err != nil {
+		if errors.Is(err, models.ErrCompanyDoesntExists) {
+			return models.ErrPermissionDenied
+		}
+		return err
+	}
+	if company.PublicID == nil || *company.PublicID != companyPublicID {
+		return models.ErrPermissionDenied
+	}
+	return nil
+}
+
+// IsBankQuestionRecruiter allows the recruiters of the company owning the bank question.
+func (a *authorizationService) IsBankQuestionRecruiter(ctx context.Context, recruiterPublicID, bankQuestionPublicID string) error {
+	companyPublicID, err := a.bankRepo.GetBankQuestionCompany(ctx, bankQuestionPublicID)
+	if err != nil {
+		return err
+	}
+	return a.IsCompanyMember(ctx, recruiterPublicID, companyPublicID)
+}
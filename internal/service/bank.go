@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type questionBankService struct {
+	cfg      *config.Configs
+	logger   *zap.SugaredLogger
+	bankRepo repository.QuestionBankRepository
+}
+
+func NewQuestionBankService(repo *repository.Repository, cfg *config.Configs, logger *zap.SugaredLogger) QuestionBankService {
+	return &questionBankService{
+		bankRepo: repo.QuestionBankRepository,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+// normalizeTags lowercases and trims tags and drops empty and repeated ones.
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, tag)
+	}
+	return res
+}
+
+func (s *questionBankService) CreateBankQuestion(ctx context.Context, q *models.BankQuestion) (*models.BankQuestion, error) {
+	if q.Name == nil || strings.TrimSpace(*q.Name) == "" || q.Type == nil {
+		return nil, models.ErrInvalidInput
+	}
+	q.Tags = normalizeTags(q.Tags)
+	if q.Tags == nil {
+		q.Tags = []string{}
+	}
+	return s.bankRepo.CreateBankQuestion(ctx, q)
+}
+
+func (s *questionBankService) GetBankQuestions(ctx context.Context, companyPublicID string, filter *models.BankFilter, page *models.Pagination) ([]*models.BankQuestion, int, error) {
+	filter.Tags = normalizeTags(filter.Tags)
+	return s.bankRepo.GetBankQuestions(ctx, companyPublicID, filter, page)
+}
+
+func (s *questionBankService) UpdateBankQuestion(ctx context.Context, q *models.BankQuestion) (*models.BankQuestion, error) {
+	if q.Name != nil && strings.TrimSpace(*q.Name) == "" {
+		return nil, models.ErrInvalidInput
+	}
+	q.Tags = normalizeTags(q.Tags)
+	return s.bankRepo.UpdateBankQuestion(ctx, q)
+}
+
+func (s *questionBankService) DeleteBankQuestion(ctx context.Context, publicID string) error {
+	return s.bankRepo.DeleteBankQuestion(ctx, publicID)
+}
+
+func (s *questionBankService) AttachBankQuestions(ctx context.Context, positionPublicID string, attachments []models.BankAttachment) ([]*models.Question, error) {
+	if len(attachments) == 0 {
+		return nil, models.ErrInvalidInput
+	}
+	return s.bankRepo.AttachBankQuestions(ctx, positionPublicID, attachments)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
+)
+
+// GetCandidateInterviews returns the interviews of a candidate. Results are
+// included once evaluated, reduced to what the position lets candidates see.
+func (s *interviewsService) GetCandidateInterviews(ctx context.Context, candidatePublicID string, page *models.Pagination) ([]*models.CandidateInterview, int, error) {
+	interviews, count, err := s.interviewRepo.GetCandidateInterviews(ctx, candidatePublicID, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, interview := range interviews {
+		if interview.Status != models.InterviewStatusEvaluated || interview.RawResult == nil {
+			continue
+		}
+		result := &models.Result{}
+		if err := json.Unmarshal(interview.RawResult, result); err != nil {
+			requestid.Logger(ctx, s.logger).Errorf("invalid results of interview %s: %v", interview.PublicID, err)
+			return nil, 0, err
+		}
+		var scoring *models.Scoring
+		if interview.RawScoring != nil {
+			scoring = &models.Scoring{}
+			if err := json.Unmarshal(interview.RawScoring, scoring); err != nil {
+				requestid.Logger(ctx, s.logger).Errorf("invalid scoring of interview %s: %v", interview.PublicID, err)
+				return nil, 0, err
+			}
+		}
+		visibility := models.ResultVisibilityHidden
+		if interview.Position.ResultVisibility != nil {
+			visibility = *interview.Position.ResultVisibility
+		}
+		interview.Result = models.CandidateResultView(result, scoring, visibility)
+	}
+	return interviews, count, nil
+}
+
+func (s *interviewsService) GetCandidateApplications(ctx context.Context, candidatePublicID string, page *models.Pagination) ([]*models.Application, int, error) {
+	return s.interviewRepo.GetCandidateApplications(ctx, candidatePublicID, s.cfg.Interview.MaxAttempts, page)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
+)
+
+// mergeEmotions turns raw emotion results into a timeline ordered by start,
+// joining overlapping or touching segments of the same emotion.
+func mergeEmotions(results []models.EmotionResult) []models.EmotionSegment {
+	segments := make([]models.EmotionSegment, 0, len(results))
+	for _, r := range results {
+		emotion := models.NormalizeEmotion(r.Emotion)
+		if emotion == "" || r.Duration <= 0 {
+			continue
+		}
+		segments = append(segments, models.EmotionSegment{
+			Emotion: emotion,
+			Start:   r.ExactTime,
+			End:     r.ExactTime + r.Duration,
+		})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		if segments[i].Emotion != segments[j].Emotion {
+			return segments[i].Emotion < segments[j].Emotion
+		}
+		return segments[i].Start < segments[j].Start
+	})
+
+	merged := make([]models.EmotionSegment, 0, len(segments))
+	for _, s := range segments {
+		last := len(merged) - 1
+		if last >= 0 && merged[last].Emotion == s.Emotion && s.Start <= merged[last].End {
+			merged[last].End = math.Max(merged[last].End, s.End)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Start < merged[j].Start })
+	return merged
+}
+
+// emotionTotals adds the duration of every segment to its emotion.
+func emotionTotals(totals map[string]float64, segments []models.EmotionSegment) {
+	for _, s := range segments {
+		totals[s.Emotion] += s.End - s.Start
+	}
+}
+
+// emotionShares converts durations per emotion into percentages of their sum,
+// the most present emotion first.
+func emotionShares(totals map[string]float64) []models.EmotionShare {
+	var sum float64
+	for _, d := range totals {
+		sum += d
+	}
+	shares := make([]models.EmotionShare, 0, len(totals))
+	for emotion, d := range totals {
+		share := models.EmotionShare{Emotion: emotion, Duration: round2(d)}
+		if sum > 0 {
+			share.Share = round2(d * 100 / sum)
+		}
+		shares = append(shares, share)
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Duration != shares[j].Duration {
+			return shares[i].Duration > shares[j].Duration
+		}
+		return shares[i].Emotion < shares[j].Emotion
+	})
+	return shares
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// GetInterviewEmotions returns the merged emotion timeline of every question of
+// the interview with per question and overall shares.
+func (s *interviewsService) GetInterviewEmotions(ctx context.Context, publicID string) (*models.InterviewEmotions, error) {
+	if _, err := s.interviewRepo.GetInterview(ctx, publicID); err != nil {
+		return nil, err
+	}
+	results, err := s.loadResults(ctx, publicID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.InterviewEmotions{
+		InterviewPublicID: publicID,
+		Questions:         []models.QuestionEmotions{},
+		Shares:            []models.EmotionShare{},
+	}
+	if len(results) == 0 {
+		return res, nil
+	}
+
+	totals := map[string]float64{}
+	for _, q := range results[0].Questions {
+		timeline := mergeEmotions(q.EmotionResults)
+		questionTotals := map[string]float64{}
+		emotionTotals(questionTotals, timeline)
+		emotionTotals(totals, timeline)
+		res.Questions = append(res.Questions, models.QuestionEmotions{
+			QuestionPublicID: q.QuestionPublicID,
+			Question:         q.Question,
+			Timeline:         timeline,
+			Shares:           emotionShares(questionTotals),
+		})
+	}
+	res.Shares = emotionShares(totals)
+	return res, nil
+}
+
+// GetPositionEmotions aggregates the emotion shares of all evaluated interviews
+// of a position, per question and overall.
+func (s *interviewsService) GetPositionEmotions(ctx context.Context, positionPublicID string) (*models.PositionEmotions, error) {
+	exists, err := s.positionRepo.Exists(ctx, positionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.ErrPositionNotFound
+	}
+	results, err := s.loadResults(ctx, "", positionPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	type question struct {
+		emotions models.QuestionEmotions
+		totals   map[string]float64
+	}
+	var order []string
+	questions := map[string]*question{}
+	totals := map[string]float64{}
+	for _, result := range results {
+		for _, q := range result.Questions {
+			key := q.QuestionPublicID
+			if key == "" {
+				key = q.Question
+			}
+			agg, ok := questions[key]
+			if !ok {
+				agg = &question{
+					emotions: models.QuestionEmotions{QuestionPublicID: q.QuestionPublicID, Question: q.Question},
+					totals:   map[string]float64{},
+				}
+				questions[key] = agg
+				order = append(order, key)
+			}
+			timeline := mergeEmotions(q.EmotionResults)
+			emotionTotals(agg.totals, timeline)
+			emotionTotals(totals, timeline)
+		}
+	}
+
+	res := &models.PositionEmotions{
+		PositionPublicID: positionPublicID,
+		Interviews:       len(results),
+		Questions:        make([]models.QuestionEmotions, 0, len(order)),
+		Shares:           emotionShares(totals),
+	}
+	for _, key := range order {
+		agg := questions[key]
+		agg.emotions.Shares = emotionShares(agg.totals)
+		res.Questions = append(res.Questions, agg.emotions)
+	}
+	return res, nil
+}
+
+func (s *interviewsService) loadResults(ctx context.Context, interviewPublicID, positionPublicID string) ([]*models.Result, error) {
+	raw, err := s.interviewRepo.GetResults(ctx, interviewPublicID, positionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*models.Result, 0, len(raw))
+	for _, r := range raw {
+		result := &models.Result{}
+		if err := json.Unmarshal(r.Result, result); err != nil {
+			requestid.Logger(ctx, s.logger).Errorf("invalid results of interview %s: %v", r.PublicID, err)
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type interviewsService struct {
+	cfg           *config.Configs
+	logger        *zap.SugaredLogger
+	interviewRepo repository.InterviewRepository
+	positionRepo  repository.PositionRepository
+}
+
+func NewInterviewsService(repo *repository.Repository, cfg *config.Configs, logger *zap.SugaredLogger) InterviewService {
+	return &interviewsService{
+		interviewRepo: repo.InterviewRepository,
+		positionRepo:  repo.PositionRepository,
+		cfg:           cfg,
+		logger:        logger,
+	}
+}
+
+// interviewStatusTransitions lists the statuses an interview may move to from each status.
+var interviewStatusTransitions = map[string][]string{
+	models.InterviewStatusScheduled:  {models.InterviewStatusInProgress, models.InterviewStatusCancelled, models.InterviewStatusExpired},
+	models.InterviewStatusInProgress: {models.InterviewStatusSubmitted, models.InterviewStatusCancelled, models.InterviewStatusExpired},
+	models.InterviewStatusSubmitted:  {models.InterviewStatusEvaluated},
+	models.InterviewStatusEvaluated:  {},
+	models.InterviewStatusExpired:    {},
+	models.InterviewStatusCancelled:  {},
+}
+
+func canTransitionInterview(from, to string) bool {
+	for _, next := range interviewStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInterview returns the interview with its questions as the viewer may see
+// them. The position is left out once it has been deleted.
+func (s *interviewsService) GetInterview(ctx context.Context, viewer *models.Viewer, publicID string) (*models.InterviewDetail, error) {
+	interview, err := s.interviewRepo.GetInterview(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.interviewRepo.GetInterviewQuestions(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	detail := &models.InterviewDetail{
+		Interview: *models.InterviewForView(interview, viewer.View),
+		Questions: models.QuestionsView(questions, viewer.View),
+	}
+
+	exists, err := s.positionRepo.Exists(ctx, interview.PositionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		detail.Position, err = s.positionRepo.GetPosition(ctx, interview.PositionPublicID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return detail, nil
+}
+
+// StartInterview starts the interview and returns it as the viewer may see it.
+func (s *interviewsService) StartInterview(ctx context.Context, viewer *models.Viewer, publicID string) (*models.Interview, error) {
+	current, err := s.transitionable(ctx, publicID, models.InterviewStatusInProgress)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.interviewRepo.StartInterview(ctx, current.PublicID, s.cfg.Interview.Duration); err != nil {
+		return nil, err
+	}
+	return s.interviewView(ctx, viewer, publicID)
+}
+
+func (s *interviewsService) SubmitInterview(ctx context.Context, viewer *models.Viewer, publicID string) (*models.Interview, error) {
+	return s.finish(ctx, viewer, publicID, models.InterviewStatusSubmitted)
+}
+
+func (s *interviewsService) CancelInterview(ctx context.Context, viewer *models.Viewer, publicID string) (*models.Interview, error) {
+	return s.finish(ctx, viewer, publicID, models.InterviewStatusCancelled)
+}
+
+// ExpireInterviews expires every interview whose deadline has passed.
+func (s *interviewsService) ExpireInterviews(ctx context.Context) (int64, error) {
+	return s.interviewRepo.ExpireInterviews(ctx)
+}
+
+// ScoreInterviews scores the evaluated interviews that have no scoring yet.
+func (s *interviewsService) ScoreInterviews(ctx context.Context) (int64, error) {
+	return s.interviewRepo.ScoreInterviews(ctx, s.cfg.Evaluation)
+}
+
+func (s *interviewsService) finish(ctx context.Context, viewer *models.Viewer, publicID, status string) (*models.Interview, error) {
+	current, err := s.transitionable(ctx, publicID, status)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.interviewRepo.FinishInterview(ctx, publicID, current.Status, status); err != nil {
+		return nil, err
+	}
+	return s.interviewView(ctx, viewer, publicID)
+}
+
+// interviewView returns the interview as the viewer may see it.
+func (s *interviewsService) interviewView(ctx context.Context, viewer *models.Viewer, publicID string) (*models.Interview, error) {
+	interview, err := s.interviewRepo.GetInterview(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	return models.InterviewForView(interview, viewer.View), nil
+}
+
+// transitionable returns the interview if it may move to status. Interviews past
+// their deadline are reported expired even before the sweeper marks them.
+func (s *interviewsService) transitionable(ctx context.Context, publicID, status string) (*models.Interview, error) {
+	current, err := s.interviewRepo.GetInterview(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if !canTransitionInterview(current.Status, status) {
+		return nil, models.ErrInvalidTransition
+	}
+	if current.ExpiresAt != nil && !current.ExpiresAt.After(time.Now()) && canTransitionInterview(current.Status, models.InterviewStatusExpired) {
+		return nil, models.ErrInterviewExpired
+	}
+	return current, nil
+}
+
+func (s *interviewsService) GetLeaderboard(ctx context.Context, positionPublicID string, filter *models.LeaderboardFilter, page *models.Pagination) ([]*models.LeaderboardEntry, int, error) {
+	exists, err := s.positionRepo.Exists(ctx, positionPublicID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !exists {
+		return nil, 0, models.ErrPositionNotFound
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []string{models.InterviewStatusEvaluated}
+	}
+	return s.interviewRepo.GetLeaderboard(ctx, positionPublicID, filter, page)
+}
+
+// GetPositionStats summarizes the interviews of a position. The completion rate
+// is the share of all its interviews that were submitted or evaluated.
+func (s *interviewsService) GetPositionStats(ctx context.Context, positionPublicID string, buckets int) (*models.PositionStats, error) {
+	exists, err := s.positionRepo.Exists(ctx, positionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.ErrPositionNotFound
+	}
+
+	stats, err := s.interviewRepo.GetPositionStats(ctx, positionPublicID, buckets)
+	if err != nil {
+		return nil, err
+	}
+	if stats.InterviewCount > 0 {
+		completed := stats.StatusCounts[models.InterviewStatusSubmitted] + stats.StatusCounts[models.InterviewStatusEvaluated]
+		stats.CompletionRate = float64(completed) / float64(stats.InterviewCount)
+	}
+	return stats, nil
+}
@@ -1,42 +1,93 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"strings"
 
 	"github.com/Zhiyenbek/sp-positions-main-service/config"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/requestid"
 	"go.uber.org/zap"
 )
 
 type positionsService struct {
-	cfg          *config.Configs
-	logger       *zap.SugaredLogger
-	positionRepo repository.PositionRepository
-	companyRepo  repository.CompanyRepository
+	cfg           *config.Configs
+	logger        *zap.SugaredLogger
+	positionRepo  repository.PositionRepository
+	companyRepo   repository.CompanyRepository
+	interviewRepo repository.InterviewRepository
 }
 
 func NewPositionsService(repo *repository.Repository, cfg *config.Configs, logger *zap.SugaredLogger) PositionService {
 	return &positionsService{
-		positionRepo: repo.PositionRepository,
-		companyRepo:  repo.CompanyRepository,
-		cfg:          cfg,
-		logger:       logger,
+		positionRepo:  repo.PositionRepository,
+		companyRepo:   repo.CompanyRepository,
+		interviewRepo: repo.InterviewRepository,
+		cfg:           cfg,
+		logger:        logger,
 	}
 }
 
-func (p *positionsService) GetAllPositions(search string, pageNum, pageSize int) ([]models.Position, int, error) {
-	return p.positionRepo.GetAllPositions(search, pageNum, pageSize)
+func (p *positionsService) GetAllPositions(ctx context.Context, filter *models.PositionFilter, page *models.Pagination) ([]models.Position, *models.PageInfo, error) {
+	return p.positionRepo.GetAllPositions(ctx, filter, page)
 }
 
-func (p *positionsService) GetPosition(publicID string) (*models.Position, error) {
-	return p.positionRepo.GetPosition(publicID)
+func (p *positionsService) GetPosition(ctx context.Context, publicID string) (*models.Position, error) {
+	return p.positionRepo.GetPosition(ctx, publicID)
 }
 
-func (p *positionsService) GetPositionInterviews(publicID string, pageNum int, pageSize int) ([]*models.Interview, int, error) {
-	interviewRawResult, count, err := p.positionRepo.GetPositionInterviews(publicID, pageNum, pageSize)
+// GetPositionInterviews lists the interviews of a position as the viewer may see
+// them. Only recruiters may filter by decision.
+func (p *positionsService) GetPositionInterviews(ctx context.Context, viewer *models.Viewer, publicID string, filter *models.InterviewFilter, page *models.Pagination) ([]models.InterviewView, *models.PageInfo, error) {
+	if viewer.View != models.ViewRecruiter {
+		filter = &models.InterviewFilter{}
+	}
+	interviews, info, err := p.positionInterviews(ctx, publicID, filter, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	visibility := models.ResultVisibilityHidden
+	if viewer.View == models.ViewCandidate {
+		position, err := p.positionRepo.GetPosition(ctx, publicID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if position.ResultVisibility != nil {
+			visibility = *position.ResultVisibility
+		}
+	}
+
+	res := make([]models.InterviewView, 0, len(interviews))
+	for _, interview := range interviews {
+		switch {
+		case viewer.View == models.ViewRecruiter:
+			res = append(res, interview)
+		case viewer.View == models.ViewCandidate && interview.CandidatePublicID == viewer.PublicID:
+			view := &models.CandidateInterview{
+				PublicID: interview.PublicID,
+				Status:   interview.Status,
+			}
+			if interview.Status == models.InterviewStatusEvaluated {
+				view.Result = models.CandidateResultView(interview.Result, interview.Scoring, visibility)
+			}
+			res = append(res, view)
+		default:
+			res = append(res, &models.PublicInterview{
+				PublicID: interview.PublicID,
+				Status:   interview.Status,
+			})
+		}
+	}
+	return res, info, nil
+}
+
+func (p *positionsService) positionInterviews(ctx context.Context, publicID string, filter *models.InterviewFilter, page *models.Pagination) ([]*models.Interview, *models.PageInfo, error) {
+	interviewRawResult, info, err := p.positionRepo.GetPositionInterviews(ctx, publicID, filter, page)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 	res := make([]*models.Interview, 0)
 	for _, r := range interviewRawResult {
@@ -45,28 +96,54 @@ func (p *positionsService) GetPositionInterviews(publicID string, pageNum int, p
 			interview := &models.Interview{}
 			err = json.Unmarshal(r.Result, &result)
 			if err != nil {
-				p.logger.Error(err)
-				return nil, 0, err
+				requestid.Logger(ctx, p.logger).Error(err)
+				return nil, nil, err
 			}
 			interview.PublicID = r.PublicID
 			interview.CandidatePublicID = r.CandidatePublicID
-			interview.Result = *result
+			interview.Status = r.Status
+			interview.Decision = r.Decision
+			interview.Result = result
+			if r.Scoring != nil {
+				interview.Scoring = &models.Scoring{}
+				if err := json.Unmarshal(r.Scoring, interview.Scoring); err != nil {
+					requestid.Logger(ctx, p.logger).Error(err)
+					return nil, nil, err
+				}
+			}
 			res = append(res, interview)
 		} else {
 			res = append(res, &models.Interview{
-				PublicID: r.PublicID,
+				PublicID:          r.PublicID,
+				CandidatePublicID: r.CandidatePublicID,
+				Status:            r.Status,
+				Decision:          r.Decision,
 			})
 		}
 	}
-	return res, count, nil
+
+	ids := make([]string, 0, len(res))
+	for _, interview := range res {
+		if interview.Result != nil {
+			ids = append(ids, interview.PublicID)
+		}
+	}
+	if len(ids) > 0 {
+		answers, err := p.interviewRepo.GetAnswers(ctx, ids)
+		if err != nil {
+			return nil, nil, err
+		}
+		attachAnswers(res, answers)
+	}
+	return res, info, nil
 }
 
-func (p *positionsService) CreatePosition(position *models.Position) (*models.Position, error) {
-	publicID, err := p.positionRepo.CreatePosition(position)
+func (p *positionsService) CreatePosition(ctx context.Context, position *models.Position) (*models.Position, error) {
+	publicID, err := p.positionRepo.CreatePosition(ctx, position)
 	if err != nil {
 		return nil, err
 	}
-	company, err := p.companyRepo.GetCompanyByRecruiterPublicID(*position.RecruiterPublicID)
+	company, err := p.companyRepo.GetCompanyByRecruiterPublicID(ctx, *position.RecruiterPublicID)
 	if err != nil {
 		return nil, err
 	}
@@ -75,8 +152,85 @@ func (p *positionsService) CreatePosition(position *models.Position) (*models.Po
 	return position, nil
 }
 
-func (p *positionsService) Exists(publicID string) error {
-	exists, err := p.positionRepo.Exists(publicID)
+// ImportPositions creates all positions with their questions, or none of them.
+func (p *positionsService) ImportPositions(ctx context.Context, positions []*models.PositionWithQuestions) ([]string, error) {
+	for _, position := range positions {
+		for _, q := range position.Questions {
+			q.Skills = questionSkills(q.Skills)
+		}
+	}
+	return p.positionRepo.ImportPositions(ctx, positions)
+}
+
+// positionStatusTransitions lists the statuses a position may move to from each status.
+var positionStatusTransitions = map[int][]int{
+	models.PositionStatusDraft:    {models.PositionStatusOpen, models.PositionStatusArchived},
+	models.PositionStatusOpen:     {models.PositionStatusPaused, models.PositionStatusClosed},
+	models.PositionStatusPaused:   {models.PositionStatusOpen, models.PositionStatusClosed},
+	models.PositionStatusClosed:   {models.PositionStatusOpen, models.PositionStatusArchived},
+	models.PositionStatusArchived: {},
+}
+
+func canTransitionPosition(from, to int) bool {
+	for _, next := range positionStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdatePosition edits a position. A status change must be a valid transition
+// from the current status and fails if the status changes concurrently.
+func (p *positionsService) UpdatePosition(ctx context.Context, position *models.Position) (*models.Position, error) {
+	current, err := p.positionRepo.GetPosition(ctx, *position.PublicID)
+	if err != nil {
+		return nil, err
+	}
+	if position.Status != nil && (current.Status == nil ||
+		*position.Status != *current.Status && !canTransitionPosition(*current.Status, *position.Status)) {
+		return nil, models.ErrInvalidTransition
+	}
+
+	if err := p.positionRepo.UpdatePosition(ctx, position, current.Status); err != nil {
+		return nil, err
+	}
+	return p.positionRepo.GetPosition(ctx, *position.PublicID)
+}
+
+func (p *positionsService) ChangePositionStatus(ctx context.Context, publicID string, status int) (*models.Position, error) {
+	current, err := p.positionRepo.GetPosition(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == nil || !canTransitionPosition(*current.Status, status) {
+		return nil, models.ErrInvalidTransition
+	}
+
+	if err := p.positionRepo.UpdatePositionStatus(ctx, publicID, *current.Status, status); err != nil {
+		return nil, err
+	}
+	return p.positionRepo.GetPosition(ctx, publicID)
+}
+
+// DeletePosition hides the position from listings, or removes it with all
+// of its questions and interviews when hard is set.
+func (p *positionsService) DeletePosition(ctx context.Context, publicID string, hard bool) error {
+	if hard {
+		return p.positionRepo.DeletePosition(ctx, publicID)
+	}
+	return p.positionRepo.SoftDeletePosition(ctx, publicID)
+}
+
+func (p *positionsService) RestorePosition(ctx context.Context, publicID string) (*models.Position, error) {
+	if err := p.positionRepo.RestorePosition(ctx, publicID); err != nil {
+		return nil, err
+	}
+	return p.positionRepo.GetPosition(ctx, publicID)
+}
+
+func (p *positionsService) Exists(ctx context.Context, publicID string) error {
+	exists, err := p.positionRepo.Exists(ctx, publicID)
 	if err != nil {
 		return err
 	}
@@ -86,36 +240,114 @@ func (p *positionsService) Exists(publicID string) error {
 	return nil
 }
 
-func (p *positionsService) CreateSkillsForPosition(positionPublicID string, skills []string) error {
-	return p.positionRepo.CreateSkillsForPosition(positionPublicID, skills)
+func (p *positionsService) CreateSkillsForPosition(ctx context.Context, positionPublicID string, skills []string) error {
+	return p.positionRepo.CreateSkillsForPosition(ctx, positionPublicID, skills)
 }
 
-func (p *positionsService) DeleteSkillsFromPosition(positionPublicID string, skills []string) error {
-	return p.positionRepo.DeleteSkillsFromPosition(positionPublicID, skills)
+func (p *positionsService) DeleteSkillsFromPosition(ctx context.Context, positionPublicID string, skills []string) error {
+	return p.positionRepo.DeleteSkillsFromPosition(ctx, positionPublicID, skills)
 }
 
-func (p *positionsService) GetPositionsByCompany(companyID string, pageNum int, pageSize int, search string) ([]models.Position, int, error) {
-	return p.positionRepo.GetPositionsByCompany(companyID, pageNum, pageSize, search)
+func (p *positionsService) GetPositionsByCompany(ctx context.Context, companyID string, page *models.Pagination, filter *models.PositionFilter) ([]models.Position, *models.PageInfo, error) {
+	return p.positionRepo.GetPositionsByCompany(ctx, companyID, page, filter)
 }
 
-func (p *positionsService) GetPositionsByRecruiter(recruiterID string, pageNum int, pageSize int, search string) ([]models.Position, int, error) {
-	return p.positionRepo.GetPositionsByRecruiter(recruiterID, pageNum, pageSize, search)
+func (p *positionsService) GetPositionsByRecruiter(ctx context.Context, recruiterID string, page *models.Pagination, filter *models.PositionFilter) ([]models.Position, *models.PageInfo, error) {
+	return p.positionRepo.GetPositionsByRecruiter(ctx, recruiterID, page, filter)
 }
 
-func (p *positionsService) AddQuestionsToPosition(positionPublicID string, questions []*models.Question) ([]*models.Question, error) {
-	return p.positionRepo.AddQuestionsToPosition(positionPublicID, questions)
+func (p *positionsService) AddQuestionsToPosition(ctx context.Context, positionPublicID string, questions []*models.Question) ([]*models.Question, error) {
+	for _, q := range questions {
+		q.Skills = questionSkills(q.Skills)
+	}
+	return p.positionRepo.AddQuestionsToPosition(ctx, positionPublicID, questions)
 }
 
-func (p *positionsService) GetPositionQuestions(positionPublicID string) ([]*models.Question, error) {
-	return p.positionRepo.GetPositionQuestions(positionPublicID)
+// ReorderQuestions sets the order of all questions of a position and returns them in it.
+func (p *positionsService) ReorderQuestions(ctx context.Context, positionPublicID string, questionPublicIDs []string) ([]*models.Question, error) {
+	seen := make(map[string]bool, len(questionPublicIDs))
+	for _, id := range questionPublicIDs {
+		if seen[id] {
+			return nil, models.ErrInvalidInput
+		}
+		seen[id] = true
+	}
+	if err := p.positionRepo.ReorderQuestions(ctx, positionPublicID, questionPublicIDs); err != nil {
+		return nil, err
+	}
+	return p.positionRepo.GetPositionQuestions(ctx, positionPublicID)
 }
 
-func (p *positionsService) CreateInterview(positionPublicID, candidatePublicID string) (string, error) {
-	return p.positionRepo.CreateInterview(positionPublicID, candidatePublicID)
+// GetRubric returns the rubric of a position with unset values filled from the config.
+func (p *positionsService) GetRubric(ctx context.Context, positionPublicID string) (*models.Rubric, error) {
+	rubric, err := p.positionRepo.GetRubric(ctx, positionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	return withRubricDefaults(rubric, p.cfg.Evaluation), nil
+}
+
+// UpdateRubric re-weights the questions of a position and rescores its
+// evaluated interviews.
+func (p *positionsService) UpdateRubric(ctx context.Context, positionPublicID string, rubric *models.Rubric) (*models.Rubric, error) {
+	seen := make(map[string]bool, len(rubric.Questions))
+	for _, q := range rubric.Questions {
+		if seen[q.QuestionPublicID] {
+			return nil, models.ErrInvalidInput
+		}
+		seen[q.QuestionPublicID] = true
+	}
+	if err := p.positionRepo.UpdateRubric(ctx, positionPublicID, rubric, p.cfg.Evaluation); err != nil {
+		return nil, err
+	}
+	return p.GetRubric(ctx, positionPublicID)
+}
+
+// questionSkills trims skill names and drops empty and repeated ones.
+func questionSkills(skills []string) []string {
+	if skills == nil {
+		return nil
+	}
+	res := make([]string, 0, len(skills))
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		skill = strings.TrimSpace(skill)
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		res = append(res, skill)
+	}
+	return res
+}
+
+// GetPositionQuestions returns the questions of a position as the viewer may see them.
+func (p *positionsService) GetPositionQuestions(ctx context.Context, viewer *models.Viewer, positionPublicID string) ([]*models.Question, error) {
+	questions, err := p.positionRepo.GetPositionQuestions(ctx, positionPublicID)
+	if err != nil {
+		return nil, err
+	}
+	return models.QuestionsView(questions, viewer.View), nil
+}
+
+// CreateInterview schedules an interview for the candidate, or returns their
+// active one for the position with created set to false.
+func (p *positionsService) CreateInterview(ctx context.Context, positionPublicID, candidatePublicID string) (string, bool, error) {
+	if err := p.Exists(ctx, positionPublicID); err != nil {
+		return "", false, err
+	}
+	position, err := p.positionRepo.GetPosition(ctx, positionPublicID)
+	if err != nil {
+		return "", false, err
+	}
+	if position.Status == nil || *position.Status != models.PositionStatusOpen {
+		return "", false, models.ErrPositionNotOpen
+	}
+	return p.positionRepo.CreateInterview(ctx, positionPublicID, candidatePublicID, p.cfg.Interview.TTL, p.cfg.Interview.MaxAttempts)
 }
 
-func (p *positionsService) DeleteQuestion(publicID string) error {
-	exists, err := p.positionRepo.QuestionExists(publicID)
+func (p *positionsService) DeleteQuestion(ctx context.Context, publicID string) error {
+	exists, err := p.positionRepo.QuestionExists(ctx, publicID)
 	if err != nil {
 		return err
 	}
@@ -124,11 +356,12 @@ func (p *positionsService) DeleteQuestion(publicID string) error {
 		return models.ErrQuestionNotFound
 	}
 
-	return p.positionRepo.DeleteQuestion(publicID)
+	return p.positionRepo.DeleteQuestion(ctx, publicID)
 }
 
-func (p *positionsService) UpdateQuestion(q *models.Question) (*models.Question, error) {
-	exists, err := p.positionRepo.QuestionExists(q.PublicID)
+func (p *positionsService) UpdateQuestion(ctx context.Context, q *models.Question) (*models.Question, error) {
+	q.Skills = questionSkills(q.Skills)
+	exists, err := p.positionRepo.QuestionExists(ctx, q.PublicID)
 	if err != nil {
 		return nil, err
 	}
@@ -136,5 +369,5 @@ func (p *positionsService) UpdateQuestion(q *models.Question) (*models.Question,
 	if !exists {
 		return nil, models.ErrQuestionNotFound
 	}
-	return p.positionRepo.UpdateQuestion(q)
+	return p.positionRepo.UpdateQuestion(ctx, q)
 }
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// SubmitResults validates result against the interview's question snapshot and
+// rubric and stores it as a new version, scored in the same transaction. It returns the stored
+// version number.
+func (s *interviewsService) SubmitResults(ctx context.Context, publicID string, result *models.Result) (int, error) {
+	if _, err := s.interviewRepo.GetInterview(ctx, publicID); err != nil {
+		return 0, err
+	}
+	questions, err := s.interviewRepo.GetInterviewQuestions(ctx, publicID)
+	if err != nil {
+		return 0, err
+	}
+	rubric, err := s.interviewRepo.GetInterviewRubric(ctx, publicID)
+	if err != nil {
+		return 0, err
+	}
+	rubric = withRubricDefaults(rubric, s.cfg.Evaluation)
+
+	maxScores := make(map[string]int, len(rubric.Questions))
+	for _, q := range rubric.Questions {
+		maxScores[q.QuestionPublicID] = *q.MaxScore
+	}
+	if err := validateResult(result, questions, maxScores); err != nil {
+		return 0, err
+	}
+	return s.interviewRepo.SaveResults(ctx, publicID, result, s.cfg.Evaluation)
+}
+
+// validateResult checks that result answers every snapshotted question exactly
+// once, that scores are within the question's maximum and that emotions fall
+// inside the answer time. Questions without an answer duration don't bound
+// their emotions.
+func validateResult(result *models.Result, questions []*models.Question, maxScores map[string]int) error {
+	snapshot := make(map[string]*models.Question, len(questions))
+	for _, q := range questions {
+		snapshot[q.PublicID] = q
+	}
+	if len(result.Questions) != len(questions) {
+		return fmt.Errorf("%w: expected %d questions, got %d", models.ErrInvalidResult, len(questions), len(result.Questions))
+	}
+
+	seen := make(map[string]bool, len(result.Questions))
+	for i, qr := range result.Questions {
+		q, ok := snapshot[qr.QuestionPublicID]
+		if !ok {
+			return fmt.Errorf("%w: questions[%d]: unknown question_public_id %q", models.ErrInvalidResult, i, qr.QuestionPublicID)
+		}
+		if seen[qr.QuestionPublicID] {
+			return fmt.Errorf("%w: questions[%d]: duplicate question_public_id %q", models.ErrInvalidResult, i, qr.QuestionPublicID)
+		}
+		seen[qr.QuestionPublicID] = true
+
+		if qr.QuestionType != models.QuestionTypeTech && qr.QuestionType != models.QuestionTypeSoft {
+			return fmt.Errorf("%w: questions[%d]: question_type must be %q or %q", models.ErrInvalidResult, i, models.QuestionTypeTech, models.QuestionTypeSoft)
+		}
+		maxScore := maxScores[qr.QuestionPublicID]
+		if qr.Score < 0 || qr.Score > maxScore {
+			return fmt.Errorf("%w: questions[%d]: score must be between 0 and %d", models.ErrInvalidResult, i, maxScore)
+		}
+		for j, e := range qr.EmotionResults {
+			if e.Emotion == "" {
+				return fmt.Errorf("%w: questions[%d].emotion_results[%d]: emotion is empty", models.ErrInvalidResult, i, j)
+			}
+			if e.ExactTime < 0 || e.Duration < 0 {
+				return fmt.Errorf("%w: questions[%d].emotion_results[%d]: exact_time and duration must not be negative", models.ErrInvalidResult, i, j)
+			}
+			if q.AnswerDuration > 0 && e.ExactTime+e.Duration > float64(q.AnswerDuration) {
+				return fmt.Errorf("%w: questions[%d].emotion_results[%d]: must lie within the %d second answer", models.ErrInvalidResult, i, j, q.AnswerDuration)
+			}
+		}
+	}
+
+	maxTotal := 0
+	for _, q := range questions {
+		maxTotal += maxScores[q.PublicID]
+	}
+	if result.Score < 0 || result.Score > maxTotal {
+		return fmt.Errorf("%w: score must be between 0 and %d", models.ErrInvalidResult, maxTotal)
+	}
+	return nil
+}
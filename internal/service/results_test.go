@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// TestValidateResultEmotions checks that emotions must lie within the answer
+// duration of their question, unless the question has none.
+func TestValidateResultEmotions(t *testing.T) {
+	const questionPublicID = "5b3f1c8e-8f55-4d8a-9c1e-2f5d8f0e7a10"
+	tests := []struct {
+		name           string
+		answerDuration int
+		emotion        models.EmotionResult
+		valid          bool
+	}{
+		{"within the answer", 60, models.EmotionResult{Emotion: "happy", ExactTime: 10, Duration: 50}, true},
+		{"past the answer", 60, models.EmotionResult{Emotion: "happy", ExactTime: 50, Duration: 20}, false},
+		{"negative time", 60, models.EmotionResult{Emotion: "happy", ExactTime: -1, Duration: 5}, false},
+		{"no answer duration", 0, models.EmotionResult{Emotion: "happy", ExactTime: 90, Duration: 30}, true},
+		{"no answer duration, negative duration", 0, models.EmotionResult{Emotion: "happy", ExactTime: 5, Duration: -1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions := []*models.Question{{PublicID: questionPublicID, AnswerDuration: tt.answerDuration}}
+			result := &models.Result{
+				Score: 5,
+				Questions: []models.QuestionResult{{
+					QuestionPublicID: questionPublicID,
+					QuestionType:     models.QuestionTypeTech,
+					Score:            5,
+					EmotionResults:   []models.EmotionResult{tt.emotion},
+				}},
+			}
+
+			err := validateResult(result, questions, map[string]int{questionPublicID: 10})
+			if tt.valid && err != nil {
+				t.Fatalf("got %v, want no error", err)
+			}
+			if !tt.valid && !errors.Is(err, models.ErrInvalidResult) {
+				t.Fatalf("got %v, want %v", err, models.ErrInvalidResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// Decide records a recruiter decision on a finished interview.
+func (s *interviewsService) Decide(ctx context.Context, decision *models.Decision) error {
+	if _, err := models.ParseDecision(decision.Decision, false); err != nil {
+		return err
+	}
+	return s.interviewRepo.SaveDecision(ctx, decision)
+}
+
+func (s *interviewsService) GetDecisions(ctx context.Context, publicID string) ([]*models.Decision, error) {
+	if _, err := s.interviewRepo.GetInterview(ctx, publicID); err != nil {
+		return nil, err
+	}
+	return s.interviewRepo.GetDecisions(ctx, publicID)
+}
+
+func (s *interviewsService) AddNote(ctx context.Context, note *models.Note) error {
+	return s.interviewRepo.CreateNote(ctx, note)
+}
+
+func (s *interviewsService) UpdateNote(ctx context.Context, note *models.Note) error {
+	return s.interviewRepo.UpdateNote(ctx, note)
+}
+
+func (s *interviewsService) GetNotes(ctx context.Context, publicID string) ([]*models.Note, error) {
+	if _, err := s.interviewRepo.GetInterview(ctx, publicID); err != nil {
+		return nil, err
+	}
+	return s.interviewRepo.GetNotes(ctx, publicID)
+}
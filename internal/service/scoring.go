@@ -0,0 +1,27 @@
+package service
+
+import (
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+)
+
+// withRubricDefaults fills the maximum scores and pass threshold a rubric
+// leaves unset from the evaluation config.
+func withRubricDefaults(rubric *models.Rubric, cfg *config.EvaluationConf) *models.Rubric {
+	if rubric.PassThreshold == nil {
+		threshold := cfg.PassThreshold
+		rubric.PassThreshold = &threshold
+	}
+	for i := range rubric.Questions {
+		q := &rubric.Questions[i]
+		if q.Weight == nil {
+			weight := 1.0
+			q.Weight = &weight
+		}
+		if q.MaxScore == nil {
+			maxScore := cfg.MaxQuestionScore
+			q.MaxScore = &maxScore
+		}
+	}
+	return rubric
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/Zhiyenbek/sp-positions-main-service/config"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
 	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository"
@@ -8,27 +10,88 @@ import (
 )
 
 type PositionService interface {
-	GetAllPositions(search string, pageNum, pageSize int) ([]models.Position, int, error)
-	GetPositionInterviews(publicID string, pageNum int, pageSize int) ([]*models.Interview, int, error)
-	Exists(publicID string) error
-	GetPosition(publicID string) (*models.Position, error)
-	CreatePosition(position *models.Position) (*models.Position, error)
-	CreateSkillsForPosition(positionPublicID string, skills []string) error
-	DeleteSkillsFromPosition(positionPublicID string, skills []string) error
-	GetPositionsByCompany(companyID string, pageNum int, pageSize int, search string) ([]models.Position, int, error)
-	GetPositionsByRecruiter(recruiterID string, pageNum int, pageSize int, search string) ([]models.Position, int, error)
-	AddQuestionsToPosition(positionPublicID string, questions []*models.Question) ([]*models.Question, error)
-	GetPositionQuestions(positionPublicID string) ([]*models.Question, error)
-	CreateInterview(positionPublicID, candidatePublicID string) (string, error)
-	DeleteQuestion(publicID string) error
-	UpdateQuestion(q *models.Question) (*models.Question, error)
+	GetAllPositions(ctx context.Context, filter *models.PositionFilter, page *models.Pagination) ([]models.Position, *models.PageInfo, error)
+	GetPositionInterviews(ctx context.Context, viewer *models.Viewer, publicID string, filter *models.InterviewFilter, page *models.Pagination) ([]models.InterviewView, *models.PageInfo, error)
+	Exists(ctx context.Context, publicID string) error
+	GetPosition(ctx context.Context, publicID string) (*models.Position, error)
+	CreatePosition(ctx context.Context, position *models.Position) (*models.Position, error)
+	ImportPositions(ctx context.Context, positions []*models.PositionWithQuestions) ([]string, error)
+	UpdatePosition(ctx context.Context, position *models.Position) (*models.Position, error)
+	ChangePositionStatus(ctx context.Context, publicID string, status int) (*models.Position, error)
+	DeletePosition(ctx context.Context, publicID string, hard bool) error
+	RestorePosition(ctx context.Context, publicID string) (*models.Position, error)
+	CreateSkillsForPosition(ctx context.Context, positionPublicID string, skills []string) error
+	DeleteSkillsFromPosition(ctx context.Context, positionPublicID string, skills []string) error
+	GetPositionsByCompany(ctx context.Context, companyID string, page *models.Pagination, filter *models.PositionFilter) ([]models.Position, *models.PageInfo, error)
+	GetPositionsByRecruiter(ctx context.Context, recruiterID string, page *models.Pagination, filter *models.PositionFilter) ([]models.Position, *models.PageInfo, error)
+	AddQuestionsToPosition(ctx context.Context, positionPublicID string, questions []*models.Question) ([]*models.Question, error)
+	GetPositionQuestions(ctx context.Context, viewer *models.Viewer, positionPublicID string) ([]*models.Question, error)
+	CreateInterview(ctx context.Context, positionPublicID, candidatePublicID string) (string, bool, error)
+	DeleteQuestion(ctx context.Context, publicID string) error
+	UpdateQuestion(ctx context.Context, q *models.Question) (*models.Question, error)
+	ReorderQuestions(ctx context.Context, positionPublicID string, questionPublicIDs []string) ([]*models.Question, error)
+	GetRubric(ctx context.Context, positionPublicID string) (*models.Rubric, error)
+	UpdateRubric(ctx context.Context, positionPublicID string, rubric *models.Rubric) (*models.Rubric, error)
+}
+
+type AuthorizationService interface {
+	IsCompanyRecruiter(ctx context.Context, recruiterPublicID, positionPublicID string) error
+	IsQuestionRecruiter(ctx context.Context, recruiterPublicID, questionPublicID string) error
+	IsInterviewCandidate(ctx context.Context, candidatePublicID, interviewPublicID string) error
+	IsInterviewRecruiter(ctx context.Context, recruiterPublicID, interviewPublicID string) error
+	IsCompanyMember(ctx context.Context, recruiterPublicID, companyPublicID string) error
+	IsBankQuestionRecruiter(ctx context.Context, recruiterPublicID, bankQuestionPublicID string) error
+}
+
+type InterviewService interface {
+	GetInterview(ctx context.Context, viewer *models.Viewer, publicID string) (*models.InterviewDetail, error)
+	StartInterview(ctx context.Context, viewer *models.Viewer, publicID string) (*models.Interview, error)
+	SubmitInterview(ctx context.Context, viewer *models.Viewer, publicID string) (*models.Interview, error)
+	CancelInterview(ctx context.Context, viewer *models.Viewer, publicID string) (*models.Interview, error)
+	ExpireInterviews(ctx context.Context) (int64, error)
+	ScoreInterviews(ctx context.Context) (int64, error)
+	SubmitResults(ctx context.Context, publicID string, result *models.Result) (int, error)
+	RegisterAnswer(ctx context.Context, answer *models.Answer) (*models.Answer, error)
+	GetAnswers(ctx context.Context, publicID string) ([]*models.Answer, error)
+	GetLeaderboard(ctx context.Context, positionPublicID string, filter *models.LeaderboardFilter, page *models.Pagination) ([]*models.LeaderboardEntry, int, error)
+	GetPositionStats(ctx context.Context, positionPublicID string, buckets int) (*models.PositionStats, error)
+	GetInterviewEmotions(ctx context.Context, publicID string) (*models.InterviewEmotions, error)
+	GetPositionEmotions(ctx context.Context, positionPublicID string) (*models.PositionEmotions, error)
+	Decide(ctx context.Context, decision *models.Decision) error
+	GetDecisions(ctx context.Context, publicID string) ([]*models.Decision, error)
+	AddNote(ctx context.Context, note *models.Note) error
+	UpdateNote(ctx context.Context, note *models.Note) error
+	GetNotes(ctx context.Context, publicID string) ([]*models.Note, error)
+	GetCandidateInterviews(ctx context.Context, candidatePublicID string, page *models.Pagination) ([]*models.CandidateInterview, int, error)
+	GetCandidateApplications(ctx context.Context, candidatePublicID string, page *models.Pagination) ([]*models.Application, int, error)
 }
+
+type SkillService interface {
+	MergeSkills(ctx context.Context, from, into string) error
+}
+
+type QuestionBankService interface {
+	CreateBankQuestion(ctx context.Context, q *models.BankQuestion) (*models.BankQuestion, error)
+	GetBankQuestions(ctx context.Context, companyPublicID string, filter *models.BankFilter, page *models.Pagination) ([]*models.BankQuestion, int, error)
+	UpdateBankQuestion(ctx context.Context, q *models.BankQuestion) (*models.BankQuestion, error)
+	DeleteBankQuestion(ctx context.Context, publicID string) error
+	AttachBankQuestions(ctx context.Context, positionPublicID string, attachments []models.BankAttachment) ([]*models.Question, error)
+}
+
 type Service struct {
 	PositionService
+	AuthorizationService
+	SkillService
+	InterviewService
+	QuestionBankService
 }
 
 func New(repos *repository.Repository, log *zap.SugaredLogger, cfg *config.Configs) *Service {
 	return &Service{
-		PositionService: NewPositionsService(repos, cfg, log),
+		PositionService:      NewPositionsService(repos, cfg, log),
+		AuthorizationService: NewAuthorizationService(repos, cfg, log),
+		SkillService:         NewSkillService(repos, cfg, log),
+		InterviewService:     NewInterviewsService(repos, cfg, log),
+		QuestionBankService:  NewQuestionBankService(repos, cfg, log),
 	}
 }
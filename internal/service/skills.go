@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Zhiyenbek/sp-positions-main-service/config"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/models"
+	"github.com/Zhiyenbek/sp-positions-main-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type skillsService struct {
+	cfg       *config.Configs
+	logger    *zap.SugaredLogger
+	skillRepo repository.SkillRepository
+}
+
+func NewSkillService(repo *repository.Repository, cfg *config.Configs, logger *zap.SugaredLogger) SkillService {
+	return &skillsService{
+		skillRepo: repo.SkillRepository,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+func (s *skillsService) MergeSkills(ctx context.Context, from, into string) error {
+	from, into = strings.TrimSpace(from), strings.TrimSpace(into)
+	if from == "" || into == "" {
+		return models.ErrInvalidInput
+	}
+	return s.skillRepo.MergeSkills(ctx, from, into)
+}